@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/AdonaIsium/tcg-engine/internal/api"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -14,6 +15,8 @@ func main() {
 		w.Write([]byte("OK"))
 	})
 
+	r.Mount("/", api.Routes())
+
 	log.Println("Starting server on :42069")
 
 	if err := http.ListenAndServe(":8080", r); err != nil {
@@ -1,17 +1,250 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+	"github.com/AdonaIsium/tcg-engine/internal/game"
 	"github.com/go-chi/chi/v5"
 )
 
+var (
+	ErrGameNotFound = errors.New("game not found")
+	ErrGameExists   = errors.New("game already exists")
+	ErrBadRequest   = errors.New("bad request")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// Server keeps every running game in memory. The map itself is guarded by
+// mu; each game has its own lock so independent games never wait on each
+// other.
+type Server struct {
+	mu    sync.RWMutex
+	games map[string]*gameEntry
+}
+
+type gameEntry struct {
+	mu      sync.Mutex
+	game    *game.Game
+	changed chan struct{} // closed and replaced after every change
+	timed   int           // ID of the last decision given a timeout
+}
+
+func newGameEntry(g *game.Game) *gameEntry {
+	return &gameEntry{game: g, changed: make(chan struct{})}
+}
+
+// submit applies a to the game and wakes everyone waiting on changed. The
+// caller must hold mu.
+func (e *gameEntry) submit(a game.Action) error {
+	if err := e.game.Submit(a); err != nil {
+		return err
+	}
+	close(e.changed)
+	e.changed = make(chan struct{})
+	e.scheduleTimeout()
+	return nil
+}
+
+// scheduleTimeout starts the clock on a newly asked decision. When it runs
+// out the default answer is applied; if the player answered in time the
+// timeout no longer matches the pending decision and is rejected.
+func (e *gameEntry) scheduleTimeout() {
+	d := e.game.PendingDecision()
+	timeout := time.Duration(e.game.Options.DecisionTimeoutMs) * time.Millisecond
+	if d == nil || timeout <= 0 || d.ID == e.timed {
+		return
+	}
+	e.timed = d.ID
+
+	id := d.ID
+	time.AfterFunc(timeout, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		_ = e.submit(game.DecisionTimeoutAction{DecisionID: id})
+	})
+}
+
+func NewServer() *Server {
+	return &Server{games: make(map[string]*gameEntry)}
+}
+
+// Routes returns a router backed by a fresh in-memory server.
 func Routes() chi.Router {
+	return NewServer().Routes()
+}
+
+func (s *Server) Routes() chi.Router {
 	r := chi.NewRouter()
 
+	r.Post("/games", s.startGame)
+	r.Route("/games/{gameID}", func(r chi.Router) {
+		r.Get("/", s.getGame)
+		r.Post("/play", submit[game.PlayCardAction](s))
+		r.Post("/start-turn", submit[game.StartTurnAction](s))
+		r.Post("/end-turn", submit[game.EndTurnAction](s))
+		r.Post("/pass", submit[game.PassPriorityAction](s))
+		r.Post("/attack", submit[game.DeclareAttackersAction](s))
+		r.Post("/block", submit[game.DeclareBlockersAction](s))
+		r.Post("/resolve-combat", submit[game.ResolveCombatAction](s))
+		r.Post("/mulligan", submit[game.MulliganAction](s))
+		r.Post("/keep-hand", submit[game.KeepHandAction](s))
+		r.Post("/decide", submit[game.ResolveDecisionAction](s))
+		r.Get("/ws", s.serveWS)
+	})
+
 	return r
 }
 
-func startGame(w http.ResponseWriter, _ *http.Request) {
-	w.Write([]byte("Start game -- not implemented yet"))
+type createGameRequest struct {
+	PlayerIDs [2]string          `json:"player_ids"`
+	Decks     [2][]cards.CardDef `json:"decks"`
+	Options   game.Options       `json:"options"`
+}
+
+func (s *Server) startGame(w http.ResponseWriter, r *http.Request) {
+	var req createGameRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	g, err := game.NewGame(req.PlayerIDs[0], req.PlayerIDs[1], req.Decks[0], req.Decks[1], req.Options)
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: %w", ErrBadRequest, err))
+		return
+	}
+
+	s.mu.Lock()
+	if _, ok := s.games[g.ID]; ok {
+		s.mu.Unlock()
+		writeError(w, fmt.Errorf("%w: %s", ErrGameExists, g.ID))
+		return
+	}
+	s.games[g.ID] = newGameEntry(g)
+	s.mu.Unlock()
+
+	writeView(w, r, http.StatusCreated, g)
+}
+
+func (s *Server) getGame(w http.ResponseWriter, r *http.Request) {
+	entry, err := s.lookup(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	writeView(w, r, http.StatusOK, entry.game)
+}
+
+// submit returns a handler that decodes the request body into an action of
+// type A and applies it under the game's lock. An empty body leaves A at its
+// zero value.
+func submit[A game.Action](s *Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entry, err := s.lookup(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var a A
+		if err := decodeJSON(r, &a); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, err)
+			return
+		}
+
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
+
+		// Reject an unknown viewer before the action changes anything
+		if err := checkViewer(r, entry.game); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// Like the WebSocket channel, only let players act for themselves
+		if player := actingPlayer(a); player != "" && player != r.URL.Query().Get("player") {
+			writeError(w, fmt.Errorf("%w: cannot act for %s", ErrForbidden, player))
+			return
+		}
+
+		if err := entry.submit(a); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeView(w, r, http.StatusOK, entry.game)
+	}
+}
+
+// writeView responds with the game as seen by the player named in the
+// "player" query parameter, or the spectator view when there is none. The
+// caller must hold the game's lock.
+func writeView(w http.ResponseWriter, r *http.Request, status int, g *game.Game) {
+	viewer := r.URL.Query().Get("player")
+	if viewer == "" {
+		writeJSON(w, status, g.SpectatorView())
+		return
+	}
+
+	v, err := g.ViewFor(viewer)
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: %s", err, viewer))
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+func checkViewer(r *http.Request, g *game.Game) error {
+	viewer := r.URL.Query().Get("player")
+	if viewer == "" {
+		return nil
+	}
+	for _, p := range g.Players {
+		if p.PlayerID == viewer {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", game.ErrPlayerNotFound, viewer)
+}
+
+func (s *Server) lookup(r *http.Request) (*gameEntry, error) {
+	id := chi.URLParam(r, "gameID")
+
+	s.mu.RLock()
+	entry, ok := s.games[id]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrGameNotFound, id)
+	}
+	return entry, nil
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: empty body: %w", ErrBadRequest, err)
+		}
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
 }
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+	"github.com/AdonaIsium/tcg-engine/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeck(n int) []cards.CardDef {
+	deck := make([]cards.CardDef, 0, n)
+	for i := range n {
+		deck = append(deck, cards.CardDef{
+			ID:     fmt.Sprintf("grunt_%d", i),
+			Name:   "Grunt",
+			Type:   cards.TypeCreature,
+			Cost:   1,
+			Attack: 1,
+			Health: 1,
+		})
+	}
+	return deck
+}
+
+func do(t *testing.T, h http.Handler, method, path string, body any) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out), rec.Body.String())
+	return rec, out
+}
+
+func createGame(t *testing.T, h http.Handler) string {
+	t.Helper()
+
+	rec, out := do(t, h, http.MethodPost, "/games", createGameRequest{
+		PlayerIDs: [2]string{"alice", "bob"},
+		Decks:     [2][]cards.CardDef{testDeck(10), testDeck(10)},
+		Options:   game.Options{StartingHand: 3, Seed: 42},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	return out["game_id"].(string)
+}
+
+func errorCode(out map[string]any) string {
+	e, _ := out["error"].(map[string]any)
+	code, _ := e["code"].(string)
+	return code
+}
+
+func TestCreateAndGetGame(t *testing.T) {
+	h := NewServer().Routes()
+	id := createGame(t, h)
+
+	rec, out := do(t, h, http.MethodGet, "/games/"+id+"?player=alice", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, id, out["game_id"])
+	assert.Equal(t, "alice", out["active"])
+
+	players := out["players"].([]any)
+	require.Len(t, players, 2)
+	alice := players[0].(map[string]any)
+	assert.Len(t, alice["hand"], 3)
+	assert.EqualValues(t, 7, alice["deck_count"])
+	bob := players[1].(map[string]any)
+	assert.NotContains(t, bob, "hand")
+	assert.EqualValues(t, 3, bob["hand_count"])
+}
+
+func TestGetGameSpectatorAndUnknownViewer(t *testing.T) {
+	h := NewServer().Routes()
+	id := createGame(t, h)
+
+	rec, out := do(t, h, http.MethodGet, "/games/"+id, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	for _, p := range out["players"].([]any) {
+		assert.NotContains(t, p.(map[string]any), "hand")
+	}
+
+	rec, out = do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=carol", game.StartTurnAction{PlayerID: "alice"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "player_not_found", errorCode(out))
+
+	// The rejected request must not have started the turn
+	rec, out = do(t, h, http.MethodGet, "/games/"+id, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, 0, out["turn"])
+}
+
+func TestPlayCardAndTurns(t *testing.T) {
+	h := NewServer().Routes()
+	id := createGame(t, h)
+
+	rec, _ := do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec, out := do(t, h, http.MethodPost, "/games/"+id+"/play?player=alice", game.PlayCardAction{PlayerID: "alice", HandIdx: 0})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	alice := out["players"].([]any)[0].(map[string]any)
+	assert.Len(t, alice["board"], 1)
+
+	rec, out = do(t, h, http.MethodPost, "/games/"+id+"/end-turn?player=alice", game.EndTurnAction{PlayerID: "alice"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "bob", out["active"])
+}
+
+func TestErrorMapping(t *testing.T) {
+	h := NewServer().Routes()
+	id := createGame(t, h)
+
+	rec, out := do(t, h, http.MethodPost, "/games/"+id+"/end-turn?player=alice", game.EndTurnAction{PlayerID: "alice"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "turn_not_started", errorCode(out))
+
+	rec, _ = do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	tests := []struct {
+		name   string
+		path   string
+		body   any
+		status int
+		code   string
+	}{
+		{"unknown game", "/games/nope/play", game.PlayCardAction{PlayerID: "alice"}, http.StatusNotFound, "game_not_found"},
+		{"malformed body", "/games/" + id + "/play?player=alice", "not an action", http.StatusBadRequest, "bad_request"},
+		{"not your turn", "/games/" + id + "/play?player=bob", game.PlayCardAction{PlayerID: "bob"}, http.StatusConflict, "not_your_turn"},
+		{"turn already started", "/games/" + id + "/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"}, http.StatusConflict, "turn_started"},
+		{"unknown player", "/games/" + id + "/play?player=carol", game.PlayCardAction{PlayerID: "carol"}, http.StatusNotFound, "player_not_found"},
+		{"acting for another player", "/games/" + id + "/play?player=bob", game.PlayCardAction{PlayerID: "alice"}, http.StatusForbidden, "forbidden"},
+		{"acting as a spectator", "/games/" + id + "/play", game.PlayCardAction{PlayerID: "alice"}, http.StatusForbidden, "forbidden"},
+		{"bad hand index", "/games/" + id + "/play?player=alice", game.PlayCardAction{PlayerID: "alice", HandIdx: 99}, http.StatusUnprocessableEntity, "invalid_hand_index"},
+		{"wrong combat phase", "/games/" + id + "/resolve-combat?player=alice", game.ResolveCombatAction{PlayerID: "alice"}, http.StatusConflict, "wrong_combat_phase"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, out := do(t, h, http.MethodPost, tt.path, tt.body)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.code, errorCode(out))
+		})
+	}
+}
+
+func TestDescribeError_RuleViolations(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{fmt.Errorf("%w: expected 2, got 1", game.ErrTargetCount), "target_count"},
+		{fmt.Errorf("%w: s_empty", game.ErrSpellNoEffects), "spell_no_effects"},
+		{fmt.Errorf("%w: s_bolt", game.ErrNotInstantCard), "not_instant_card"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			status, detail := describeError(tt.err)
+			assert.Equal(t, http.StatusUnprocessableEntity, status)
+			assert.Equal(t, tt.code, detail.Code)
+		})
+	}
+}
+
+func TestNotEnoughEnergy(t *testing.T) {
+	h := NewServer().Routes()
+	id := createGame(t, h)
+
+	do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	rec, _ := do(t, h, http.MethodPost, "/games/"+id+"/play?player=alice", game.PlayCardAction{PlayerID: "alice", HandIdx: 0})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec, out := do(t, h, http.MethodPost, "/games/"+id+"/play?player=alice", game.PlayCardAction{PlayerID: "alice", HandIdx: 0})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "not_enough_energy", errorCode(out))
+}
+
+func TestCreateGameRejectsEmptyDeck(t *testing.T) {
+	h := NewServer().Routes()
+
+	rec, out := do(t, h, http.MethodPost, "/games", createGameRequest{
+		PlayerIDs: [2]string{"alice", "bob"},
+		Decks:     [2][]cards.CardDef{testDeck(5), nil},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "bad_request", errorCode(out))
+}
+
+func TestCreateGameEnforcesDeckRules(t *testing.T) {
+	h := NewServer().Routes()
+
+	rec, out := do(t, h, http.MethodPost, "/games", createGameRequest{
+		PlayerIDs: [2]string{"alice", "bob"},
+		Decks:     [2][]cards.CardDef{testDeck(5), testDeck(10)},
+		Options:   game.Options{DeckRules: &cards.DeckRules{MinSize: 10}},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "deck_invalid", errorCode(out))
+}
+
+func TestCreateGameRejectsInvalidCards(t *testing.T) {
+	h := NewServer().Routes()
+
+	deck := testDeck(10)
+	deck[3] = cards.CardDef{ID: "fizzle", Name: "Fizzle", Type: cards.TypeSpell}
+	rec, out := do(t, h, http.MethodPost, "/games", createGameRequest{
+		PlayerIDs: [2]string{"alice", "bob"},
+		Decks:     [2][]cards.CardDef{testDeck(10), deck},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "card_invalid", errorCode(out))
+}
+
+func createDiscardGame(t *testing.T, h http.Handler, timeoutMs int) string {
+	t.Helper()
+
+	rec, out := do(t, h, http.MethodPost, "/games", createGameRequest{
+		PlayerIDs: [2]string{"alice", "bob"},
+		Decks:     [2][]cards.CardDef{testDeck(10), testDeck(10)},
+		Options:   game.Options{StartingHand: 4, MaxHandSize: 3, HandLimit: game.HandLimitDiscard, DecisionTimeoutMs: timeoutMs, Seed: 42},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	id := out["game_id"].(string)
+
+	rec, _ = do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec, out = do(t, h, http.MethodPost, "/games/"+id+"/end-turn?player=alice", game.EndTurnAction{PlayerID: "alice"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	decision := out["decision"].(map[string]any)
+	require.Equal(t, "discard", decision["kind"])
+	assert.Len(t, decision["options"], 4)
+	return id
+}
+
+func TestDecide(t *testing.T) {
+	h := NewServer().Routes()
+	id := createDiscardGame(t, h, 0)
+
+	rec, out := do(t, h, http.MethodPost, "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "decision_pending", errorCode(out))
+
+	rec, out = do(t, h, http.MethodPost, "/games/"+id+"/decide?player=alice", game.ResolveDecisionAction{PlayerID: "alice", DecisionID: 1})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "choice_count", errorCode(out))
+
+	rec, out = do(t, h, http.MethodPost, "/games/"+id+"/decide?player=alice", game.ResolveDecisionAction{PlayerID: "alice", DecisionID: 1, Choices: []int{0}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Nil(t, out["decision"])
+	assert.Equal(t, "bob", out["active"])
+}
+
+func TestDecisionTimeout(t *testing.T) {
+	h := NewServer().Routes()
+	id := createDiscardGame(t, h, 10)
+
+	assert.Eventually(t, func() bool {
+		_, out := do(t, h, http.MethodGet, "/games/"+id, nil)
+		return out["active"] == "bob"
+	}, time.Second, 5*time.Millisecond, "the default discard should end the turn")
+
+	rec, out := do(t, h, http.MethodPost, "/games/"+id+"/decide?player=alice", game.ResolveDecisionAction{PlayerID: "alice", DecisionID: 1, Choices: []int{0}})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "no_decision_pending", errorCode(out))
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+	"github.com/AdonaIsium/tcg-engine/internal/game"
+)
+
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorMappings is checked in order with errors.Is; the first match decides
+// the response. Anything unmatched is reported as an internal error.
+var errorMappings = []errorMapping{
+	{cards.ErrDeckInvalid, http.StatusUnprocessableEntity, "deck_invalid"},
+	{cards.ErrInvalidCard, http.StatusUnprocessableEntity, "card_invalid"},
+	{ErrBadRequest, http.StatusBadRequest, "bad_request"},
+	{ErrGameNotFound, http.StatusNotFound, "game_not_found"},
+	{game.ErrPlayerNotFound, http.StatusNotFound, "player_not_found"},
+	{ErrGameExists, http.StatusConflict, "game_exists"},
+	{ErrForbidden, http.StatusForbidden, "forbidden"},
+	{game.ErrUnknownActionType, http.StatusBadRequest, "unknown_action_type"},
+
+	// Acting out of turn or in the wrong step
+	{game.ErrGameOver, http.StatusConflict, "game_over"},
+	{game.ErrNotYourTurn, http.StatusConflict, "not_your_turn"},
+	{game.ErrTurnStarted, http.StatusConflict, "turn_started"},
+	{game.ErrTurnNotStarted, http.StatusConflict, "turn_not_started"},
+	{game.ErrNoPriority, http.StatusConflict, "no_priority"},
+	{game.ErrStackNotEmpty, http.StatusConflict, "stack_not_empty"},
+	{game.ErrStackEmpty, http.StatusConflict, "stack_empty"},
+	{game.ErrWrongCombatPhase, http.StatusConflict, "wrong_combat_phase"},
+	{game.ErrNotDefendingPlayer, http.StatusConflict, "not_defending_player"},
+	{game.ErrMulliganPending, http.StatusConflict, "mulligan_pending"},
+	{game.ErrNotInMulligan, http.StatusConflict, "not_in_mulligan"},
+	{game.ErrHandKept, http.StatusConflict, "hand_kept"},
+	{game.ErrDecisionPending, http.StatusConflict, "decision_pending"},
+	{game.ErrNoDecisionPending, http.StatusConflict, "no_decision_pending"},
+	{game.ErrNotYourDecision, http.StatusConflict, "not_your_decision"},
+
+	// Well-formed requests the rules don't allow
+	{game.ErrInvalidHandIndex, http.StatusUnprocessableEntity, "invalid_hand_index"},
+	{game.ErrNotEnoughEnergy, http.StatusUnprocessableEntity, "not_enough_energy"},
+	{game.ErrMissingTarget, http.StatusUnprocessableEntity, "missing_target"},
+	{game.ErrTargetCount, http.StatusUnprocessableEntity, "target_count"},
+	{game.ErrInvalidTarget, http.StatusUnprocessableEntity, "invalid_target"},
+	{game.ErrBoardFull, http.StatusUnprocessableEntity, "board_full"},
+	{game.ErrNotInstantCard, http.StatusUnprocessableEntity, "not_instant_card"},
+	{game.ErrSpellNoEffects, http.StatusUnprocessableEntity, "spell_no_effects"},
+	{game.ErrInvalidAttacker, http.StatusUnprocessableEntity, "invalid_attacker"},
+	{game.ErrInvalidBlocker, http.StatusUnprocessableEntity, "invalid_blocker"},
+	{game.ErrSummoningSick, http.StatusUnprocessableEntity, "summoning_sick"},
+	{game.ErrCreatureExhausted, http.StatusUnprocessableEntity, "creature_exhausted"},
+	{game.ErrAlreadyAttacked, http.StatusUnprocessableEntity, "already_attacked"},
+	{game.ErrCreatureFrozen, http.StatusUnprocessableEntity, "creature_frozen"},
+	{game.ErrMustAttackTaunt, http.StatusUnprocessableEntity, "must_attack_taunt"},
+	{game.ErrMulliganLimit, http.StatusUnprocessableEntity, "mulligan_limit"},
+	{game.ErrBottomCount, http.StatusUnprocessableEntity, "bottom_count"},
+	{game.ErrChoiceCount, http.StatusUnprocessableEntity, "choice_count"},
+	{game.ErrInvalidChoice, http.StatusUnprocessableEntity, "invalid_choice"},
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status, detail := describeError(err)
+	writeJSON(w, status, errorBody{Error: detail})
+}
+
+// describeError maps err to an HTTP status and the error code clients see.
+func describeError(err error) (int, errorDetail) {
+	status, code := http.StatusInternalServerError, "internal"
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			status, code = m.status, m.code
+			break
+		}
+	}
+	return status, errorDetail{Code: code, Message: err.Error()}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AdonaIsium/tcg-engine/internal/game"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// Heartbeat settings for game channels. A connection that doesn't answer a
+// ping within pingTimeout is dropped; clients reconnect with ?last=N.
+var (
+	pingInterval = 20 * time.Second
+	pingTimeout  = 10 * time.Second
+)
+
+// Messages sent to the client. Every new log event is pushed as an "event"
+// with its index in the game log, followed by a "state" carrying the
+// refreshed view (without the log, which the events already cover).
+type wsEventMessage struct {
+	Type  string     `json:"type"` // "event"
+	Index int        `json:"index"`
+	Event game.Event `json:"event"`
+}
+
+type wsStateMessage struct {
+	Type  string     `json:"type"` // "state"
+	State *game.View `json:"state"`
+}
+
+type wsErrorMessage struct {
+	Type  string      `json:"type"` // "error"
+	Error errorDetail `json:"error"`
+}
+
+// serveWS upgrades to a WebSocket channel for one game. The "player" query
+// parameter picks whose view is streamed; without it the channel is a
+// read-only spectator feed. "last" is the index of the last log event the
+// client already has, so a reconnecting client only receives what it missed.
+//
+// Clients send actions using the same {"type","data"} envelope as replays,
+// e.g. {"type":"play_card","data":{"player_id":"alice","hand_idx":0}}.
+func (s *Server) serveWS(w http.ResponseWriter, r *http.Request) {
+	entry, err := s.lookup(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	viewer := r.URL.Query().Get("player")
+	entry.mu.Lock()
+	err = checkViewer(r, entry.game)
+	entry.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	next := 0
+	if last := r.URL.Query().Get("last"); last != "" {
+		n, err := strconv.Atoi(last)
+		if err != nil || n < -1 {
+			writeError(w, fmt.Errorf("%w: invalid last index %q", ErrBadRequest, last))
+			return
+		}
+		next = n + 1
+	}
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go func() {
+		defer cancel()
+		s.readCommands(ctx, conn, entry, viewer)
+	}()
+	go func() {
+		defer cancel()
+		heartbeat(ctx, conn)
+	}()
+
+	for {
+		entry.mu.Lock()
+		v := view(entry.game, viewer)
+		changed := entry.changed
+		entry.mu.Unlock()
+
+		if err := sendUpdate(ctx, conn, v, next); err != nil {
+			return
+		}
+		next = len(v.Log)
+
+		select {
+		case <-ctx.Done():
+			conn.Close(websocket.StatusNormalClosure, "")
+			return
+		case <-changed:
+		}
+	}
+}
+
+// sendUpdate pushes the events from index next onwards, then the state.
+func sendUpdate(ctx context.Context, conn *websocket.Conn, v *game.View, next int) error {
+	for i := next; i < len(v.Log); i++ {
+		if err := wsjson.Write(ctx, conn, wsEventMessage{Type: "event", Index: i, Event: v.Log[i]}); err != nil {
+			return err
+		}
+	}
+
+	state := *v
+	state.Log = nil
+	return wsjson.Write(ctx, conn, wsStateMessage{Type: "state", State: &state})
+}
+
+// readCommands applies every action the client sends. Failures are reported
+// back on the channel and leave the connection open.
+func (s *Server) readCommands(ctx context.Context, conn *websocket.Conn, entry *gameEntry, viewer string) {
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		if err := applyCommand(entry, viewer, data); err != nil {
+			_, detail := describeError(err)
+			if wsjson.Write(ctx, conn, wsErrorMessage{Type: "error", Error: detail}) != nil {
+				return
+			}
+		}
+	}
+}
+
+func applyCommand(entry *gameEntry, viewer string, data []byte) (err error) {
+	// readCommands runs outside net/http, so nothing else would stop an engine
+	// panic from taking the whole server down
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("command failed: %v", r)
+		}
+	}()
+
+	if viewer == "" {
+		return fmt.Errorf("%w: spectators cannot send actions", ErrForbidden)
+	}
+
+	a, err := game.UnmarshalAction(data)
+	if err != nil {
+		if errors.Is(err, game.ErrUnknownActionType) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	if _, ok := a.(game.DecisionTimeoutAction); ok {
+		return fmt.Errorf("%w: timeouts are applied by the server", ErrForbidden)
+	}
+
+	if player := actingPlayer(a); player != "" && player != viewer {
+		return fmt.Errorf("%w: cannot act for %s", ErrForbidden, player)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return entry.submit(a)
+}
+
+// actingPlayer returns the player an action is taken on behalf of, or ""
+// for actions that don't name one.
+func actingPlayer(a game.Action) string {
+	switch a := a.(type) {
+	case game.PlayCardAction:
+		return a.PlayerID
+	case game.StartTurnAction:
+		return a.PlayerID
+	case game.EndTurnAction:
+		return a.PlayerID
+	case game.PassPriorityAction:
+		return a.PlayerID
+	case game.DeclareAttackersAction:
+		return a.PlayerID
+	case game.DeclareBlockersAction:
+		return a.PlayerID
+	case game.ResolveCombatAction:
+		return a.PlayerID
+	case game.MulliganAction:
+		return a.PlayerID
+	case game.KeepHandAction:
+		return a.PlayerID
+	case game.ResolveDecisionAction:
+		return a.PlayerID
+	}
+	return ""
+}
+
+func heartbeat(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+			err := conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func view(g *game.Game, viewer string) *game.View {
+	if viewer == "" {
+		return g.SpectatorView()
+	}
+	v, _ := g.ViewFor(viewer) // viewer was checked on connect
+	return v
+}
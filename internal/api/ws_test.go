@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AdonaIsium/tcg-engine/internal/game"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wsMessage is the union of everything the server sends.
+type wsMessage struct {
+	Type  string         `json:"type"`
+	Index int            `json:"index"`
+	Event game.Event     `json:"event"`
+	State map[string]any `json:"state"`
+	Error errorDetail    `json:"error"`
+}
+
+func dialGame(t *testing.T, ctx context.Context, srv *httptest.Server, id, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/games/" + id + "/ws" + query
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+// readUntilState collects messages up to and including the next state.
+func readUntilState(t *testing.T, ctx context.Context, conn *websocket.Conn) []wsMessage {
+	t.Helper()
+
+	var msgs []wsMessage
+	for {
+		var msg wsMessage
+		require.NoError(t, wsjson.Read(ctx, conn, &msg))
+		msgs = append(msgs, msg)
+		if msg.Type == "state" || msg.Type == "error" {
+			return msgs
+		}
+	}
+}
+
+func sendAction(t *testing.T, ctx context.Context, conn *websocket.Conn, a game.Action) {
+	t.Helper()
+
+	data, err := game.MarshalAction(a)
+	require.NoError(t, err)
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, data))
+}
+
+func TestWS_StreamsEventsAndState(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := httptest.NewServer(NewServer().Routes())
+	defer srv.Close()
+	id := createGame(t, srv.Config.Handler)
+
+	conn := dialGame(t, ctx, srv, id, "?player=alice")
+
+	initial := readUntilState(t, ctx, conn)
+	require.Len(t, initial, 4, "three setup events then the state")
+	for i, msg := range initial[:3] {
+		assert.Equal(t, "event", msg.Type)
+		assert.Equal(t, i, msg.Index)
+	}
+	assert.NotEmpty(t, initial[1].Event.Private, "own opening hand is visible")
+	assert.Empty(t, initial[2].Event.Private, "opponent opening hand is hidden")
+
+	sendAction(t, ctx, conn, game.StartTurnAction{PlayerID: "alice"})
+	update := readUntilState(t, ctx, conn)
+	require.Greater(t, len(update), 1)
+	assert.Equal(t, 3, update[0].Index, "only new events are pushed")
+	assert.EqualValues(t, 1, update[len(update)-1].State["turn"])
+
+	sendAction(t, ctx, conn, game.PlayCardAction{PlayerID: "alice", HandIdx: 0})
+	update = readUntilState(t, ctx, conn)
+	alice := update[len(update)-1].State["players"].([]any)[0].(map[string]any)
+	assert.Len(t, alice["board"], 1)
+}
+
+func TestWS_ReportsErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := httptest.NewServer(NewServer().Routes())
+	defer srv.Close()
+	id := createGame(t, srv.Config.Handler)
+
+	conn := dialGame(t, ctx, srv, id, "?player=alice")
+	readUntilState(t, ctx, conn)
+
+	tests := []struct {
+		name string
+		msg  string
+		code string
+	}{
+		{"bad hand index", `{"type":"play_card","data":{"player_id":"alice","hand_idx":99}}`, "invalid_hand_index"},
+		{"acting for opponent", `{"type":"end_turn","data":{"player_id":"bob"}}`, "forbidden"},
+		{"forcing a timeout", `{"type":"decision_timeout","data":{"decision_id":1}}`, "forbidden"},
+		{"unknown action", `{"type":"cheat","data":{}}`, "unknown_action_type"},
+		{"malformed", `not json`, "bad_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(tt.msg)))
+			msgs := readUntilState(t, ctx, conn)
+			require.Len(t, msgs, 1)
+			assert.Equal(t, "error", msgs[0].Type)
+			assert.Equal(t, tt.code, msgs[0].Error.Code)
+		})
+	}
+}
+
+func TestWS_ResumeAndCrossClientUpdates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := httptest.NewServer(NewServer().Routes())
+	defer srv.Close()
+	id := createGame(t, srv.Config.Handler)
+
+	spectator := dialGame(t, ctx, srv, id, "")
+	readUntilState(t, ctx, spectator)
+
+	// A spectator can watch but not act
+	sendAction(t, ctx, spectator, game.StartTurnAction{PlayerID: "alice"})
+	msgs := readUntilState(t, ctx, spectator)
+	assert.Equal(t, "forbidden", msgs[0].Error.Code)
+
+	// Changes made over REST reach connected channels
+	rec, _ := do(t, srv.Config.Handler, "POST", "/games/"+id+"/start-turn?player=alice", game.StartTurnAction{PlayerID: "alice"})
+	require.Equal(t, 200, rec.Code)
+	msgs = readUntilState(t, ctx, spectator)
+	for _, msg := range msgs[:len(msgs)-1] {
+		assert.Empty(t, msg.Event.Private)
+	}
+
+	// Reconnecting with the last seen index only replays the missing events
+	resumed := dialGame(t, ctx, srv, id, "?player=bob&last=2")
+	msgs = readUntilState(t, ctx, resumed)
+	require.Greater(t, len(msgs), 1)
+	assert.Equal(t, 3, msgs[0].Index)
+}
+
+func TestWS_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	srv := httptest.NewServer(NewServer().Routes())
+	defer srv.Close()
+	id := createGame(t, srv.Config.Handler)
+
+	conn := dialGame(t, ctx, srv, id, "?player=alice")
+	readUntilState(t, ctx, conn)
+
+	// The server answers pings while it waits for commands
+	ctx = conn.CloseRead(ctx)
+	require.NoError(t, conn.Ping(ctx))
+}
+
+func TestWS_RejectsBadRequests(t *testing.T) {
+	srv := httptest.NewServer(NewServer().Routes())
+	defer srv.Close()
+	id := createGame(t, srv.Config.Handler)
+
+	tests := []struct {
+		name  string
+		path  string
+		code  string
+		query string
+	}{
+		{"unknown game", "/games/nope/ws", "game_not_found", ""},
+		{"unknown player", "/games/" + id + "/ws", "player_not_found", "?player=carol"},
+		{"bad resume index", "/games/" + id + "/ws", "bad_request", "?last=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, out := do(t, srv.Config.Handler, "GET", tt.path+tt.query, nil)
+			assert.GreaterOrEqual(t, rec.Code, 400)
+			assert.Equal(t, tt.code, errorCode(out))
+		})
+	}
+}
@@ -7,42 +7,199 @@ const (
 	TypeSpell    Type = "spell"
 )
 
+type Speed string
+
+const (
+	SpeedNormal  Speed = "normal"  // only on your own turn with an empty stack (default)
+	SpeedInstant Speed = "instant" // in response to a spell, or as the defender while blockers are declared
+)
+
 type EffectKind string
 
 const (
-	EffectDamage        EffectKind = "damage"          // amount -> target (player/creature)
-	EffectHeal          EffectKind = "heal"            // amount -> target (player/creature)
-	EffectDrawCards     EffectKind = "draw_cards"      // amount -> self
-	EffectBuffStatsPerm EffectKind = "buff_stats_perm" // attack_buff/health_buff -> target creature
-	EffectBuffStatsTemp EffectKind = "buff_stats_temp" // attack_buff/health_buff -> target creature
+	EffectDamage          EffectKind = "damage"            // amount -> target (player/creature)
+	EffectHeal            EffectKind = "heal"              // amount -> target (player/creature)
+	EffectDrawCards       EffectKind = "draw_cards"        // amount -> self
+	EffectBuffStatsPerm   EffectKind = "buff_stats_perm"   // attack_buff/health_buff -> target creature
+	EffectBuffStatsTemp   EffectKind = "buff_stats_temp"   // attack_buff/health_buff -> target creature
+	EffectCounter         EffectKind = "counter"           // target spell on the stack -> graveyard
+	EffectChooseOne       EffectKind = "choose_one"        // target player picks one of choices, which then resolves
+	EffectDiscover        EffectKind = "discover"          // target player picks one of the top amount (default 3) deck cards to put in hand
+	EffectDestroy         EffectKind = "destroy"           // target creature -> graveyard
+	EffectReturnToHand    EffectKind = "return_to_hand"    // target creature -> owner's hand, buffs and damage reset
+	EffectSilence         EffectKind = "silence"           // target creature loses all buffs
+	EffectFreeze          EffectKind = "freeze"            // target creature can't attack on its controller's next turn
+	EffectTransform       EffectKind = "transform"         // target creature becomes into, keeping its instance
+	EffectSummon          EffectKind = "summon"            // amount (default 1) card_id tokens -> target player's board
+	EffectResurrect       EffectKind = "resurrect"         // target creature card in a graveyard -> caster's board
+	EffectRecycle         EffectKind = "recycle"           // target graveyard card -> owner's hand
+	EffectShuffleIntoDeck EffectKind = "shuffle_into_deck" // target graveyard card -> owner's deck, then shuffle
+	EffectMill            EffectKind = "mill"              // top amount cards of target player's deck -> graveyard
+	EffectDiscard         EffectKind = "discard"           // target player discards amount cards, picked by them or at random
+	EffectScry            EffectKind = "scry"              // target player looks at the top amount cards, keeps some on top in any order, bottoms the rest
+	EffectTutor           EffectKind = "tutor"             // target player picks amount (default 1) deck cards matching filter for their hand, then shuffles
+	EffectShuffle         EffectKind = "shuffle"           // target player's deck is shuffled
 )
 
 type TargetKind string
 
 const (
-	TargetNone          TargetKind = "none"
-	TargetEnemyPlayer   TargetKind = "enemy_player"
-	TargetSelfPlayer    TargetKind = "self_player"
-	TargetAnyCreature   TargetKind = "any_creature"
-	TargetEnemyCreature TargetKind = "enemy_creature"
-	TargetAllyCreature  TargetKind = "ally_creature"
+	TargetNone           TargetKind = "none"
+	TargetEnemyPlayer    TargetKind = "enemy_player"
+	TargetSelfPlayer     TargetKind = "self_player"
+	TargetAnyCreature    TargetKind = "any_creature"
+	TargetEnemyCreature  TargetKind = "enemy_creature"
+	TargetAllyCreature   TargetKind = "ally_creature"
+	TargetSelf           TargetKind = "self"            // the card an ability belongs to
+	TargetStackSpell     TargetKind = "stack_spell"     // a spell waiting on the stack
+	TargetAllyGraveyard  TargetKind = "ally_graveyard"  // a card in the caster's graveyard
+	TargetEnemyGraveyard TargetKind = "enemy_graveyard" // a card in the opponent's graveyard
+
+	// Area and engine-picked targets: the engine finds the targets when the
+	// effect resolves, so players don't pass any.
+	TargetAllEnemyCreatures   TargetKind = "all_enemy_creatures"   // every creature the opponent controls
+	TargetAllAllyCreatures    TargetKind = "all_ally_creatures"    // every creature the caster controls
+	TargetAllCreatures        TargetKind = "all_creatures"         // every creature on both boards
+	TargetAllEnemyCharacters  TargetKind = "all_enemy_characters"  // the opponent and their creatures
+	TargetAllAllyCharacters   TargetKind = "all_ally_characters"   // the caster and their creatures
+	TargetAllCharacters       TargetKind = "all_characters"        // both players and every creature
+	TargetRandomEnemyCreature TargetKind = "random_enemy_creature" // one enemy creature, picked with the game's random source
+
+	TargetUpToN TargetKind = "up_to_n_targets" // up to max_targets creatures picked by the caster
+)
+
+type Trigger string
+
+const (
+	TriggerOnPlay      Trigger = "on_play"       // card is played from hand
+	TriggerOnDeath     Trigger = "on_death"      // creature leaves the board for the graveyard
+	TriggerOnTurnStart Trigger = "on_turn_start" // start of the controller's turn, while on board
+	TriggerOnTurnEnd   Trigger = "on_turn_end"   // end of the controller's turn, while on board
+	TriggerOnDamaged   Trigger = "on_damaged"    // creature takes damage
+	TriggerOnDraw      Trigger = "on_draw"       // card is drawn
+)
+
+type AuraScope string
+
+const (
+	AuraOtherAllyCreatures AuraScope = "other_ally_creatures" // the controller's creatures except the aura's own
+	AuraAllyCreatures      AuraScope = "ally_creatures"       // the controller's creatures, the aura's own included
+	AuraEnemyCreatures     AuraScope = "enemy_creatures"      // the opponent's creatures
+	AuraAllCreatures       AuraScope = "all_creatures"        // every creature on both boards
+)
+
+type Keyword string
+
+const (
+	KeywordHaste        Keyword = "haste"         // no summoning sickness
+	KeywordDivineShield Keyword = "divine_shield" // ignores the first damage taken
+	KeywordLifesteal    Keyword = "lifesteal"     // damage dealt heals the controller
+	KeywordPoisonous    Keyword = "poisonous"     // destroys any creature it damages
+	KeywordTaunt        Keyword = "taunt"         // while ready, attackers must attack it
+	KeywordVigilance    Keyword = "vigilance"     // attacking doesn't exhaust, so it can still block
 )
 
 type Effect struct {
-	Kind       EffectKind `json:"kind"`
-	Amount     int        `json:"amount,omitempty"`
-	BuffAttack int        `json:"attack_buff,omitempty"`
-	BuffHealth int        `json:"health_buff,omitempty"`
-	Target     TargetKind `json:"target,omitempty"`
+	Kind       EffectKind `json:"kind" yaml:"kind"`
+	Amount     int        `json:"amount,omitempty" yaml:"amount,omitempty"`
+	BuffAttack int        `json:"attack_buff,omitempty" yaml:"attack_buff,omitempty"`
+	BuffHealth int        `json:"health_buff,omitempty" yaml:"health_buff,omitempty"`
+	Target     TargetKind `json:"target,omitempty" yaml:"target,omitempty"`
+
+	// Condition, when set, must hold when the effect resolves or the effect
+	// is skipped, e.g. "count(ally_creatures) >= 3". Value replaces Amount
+	// with an expression such as "cards_in_hand". See ParseCondition and
+	// ParseValue.
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Value     string `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// Choices are the options of a choose_one effect. Like abilities they
+	// resolve without player targets.
+	Choices []Effect `json:"choices,omitempty" yaml:"choices,omitempty"`
+
+	// Into is the creature a transform effect turns its target into.
+	Into *CardDef `json:"into,omitempty" yaml:"into,omitempty"`
+
+	// CardID names the token card a summon effect creates.
+	CardID string `json:"card_id,omitempty" yaml:"card_id,omitempty"`
+
+	// Random makes a discard effect pick the cards at random instead of
+	// letting the player choose.
+	Random bool `json:"random,omitempty" yaml:"random,omitempty"`
+
+	// MaxTargets is how many creatures an up_to_n_targets effect may pick.
+	MaxTargets int `json:"max_targets,omitempty" yaml:"max_targets,omitempty"`
+
+	// Filter limits the cards a tutor effect can find. Nil matches any card.
+	Filter *CardFilter `json:"filter,omitempty" yaml:"filter,omitempty"`
+}
+
+// CardFilter matches card definitions. Empty fields match anything.
+type CardFilter struct {
+	Type    Type    `json:"type,omitempty" yaml:"type,omitempty"`
+	Keyword Keyword `json:"keyword,omitempty" yaml:"keyword,omitempty"`
+	MaxCost *int    `json:"max_cost,omitempty" yaml:"max_cost,omitempty"`
+}
+
+// Matches reports whether def passes the filter. A nil filter matches
+// everything.
+func (f *CardFilter) Matches(def *CardDef) bool {
+	if f == nil {
+		return true
+	}
+	if f.Type != "" && def.Type != f.Type {
+		return false
+	}
+	if f.Keyword != "" && !def.HasKeyword(f.Keyword) {
+		return false
+	}
+	if f.MaxCost != nil && def.Cost > *f.MaxCost {
+		return false
+	}
+	return true
+}
+
+// Ability is a group of effects that resolve automatically when Trigger fires.
+// Targets are picked by the engine, so only self/player targets make sense.
+type Ability struct {
+	Trigger Trigger  `json:"trigger" yaml:"trigger"`
+	Effects []Effect `json:"effects" yaml:"effects"`
+}
+
+// Aura is a static ability: while its creature is on the board, every
+// creature in Scope gets the buff. It ends as soon as the creature leaves.
+type Aura struct {
+	Scope      AuraScope `json:"scope" yaml:"scope"`
+	BuffAttack int       `json:"attack_buff,omitempty" yaml:"attack_buff,omitempty"`
+	BuffHealth int       `json:"health_buff,omitempty" yaml:"health_buff,omitempty"`
 }
 
 type CardDef struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Type    Type     `json:"type"`
-	Cost    int      `json:"cost"`
-	Attack  int      `json:"attack,omitempty"`
-	Health  int      `json:"health,omitempty"`
-	Text    string   `json:"text,omitempty"`
-	Effects []Effect `json:"effects,omitempty"`
+	ID        string    `json:"id" yaml:"id"`
+	Name      string    `json:"name" yaml:"name"`
+	Type      Type      `json:"type" yaml:"type"`
+	Cost      int       `json:"cost" yaml:"cost"`
+	Speed     Speed     `json:"speed,omitempty" yaml:"speed,omitempty"`
+	Attack    int       `json:"attack,omitempty" yaml:"attack,omitempty"`
+	Health    int       `json:"health,omitempty" yaml:"health,omitempty"`
+	Text      string    `json:"text,omitempty" yaml:"text,omitempty"`
+	Effects   []Effect  `json:"effects,omitempty" yaml:"effects,omitempty"`
+	Keywords  []Keyword `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Abilities []Ability `json:"abilities,omitempty" yaml:"abilities,omitempty"`
+	Auras     []Aura    `json:"auras,omitempty" yaml:"auras,omitempty"`
+}
+
+// IsInstant reports whether the card can be cast at instant speed.
+func (c *CardDef) IsInstant() bool {
+	return c.Speed == SpeedInstant
+}
+
+// HasKeyword reports whether the card definition lists the given keyword.
+func (c *CardDef) HasKeyword(k Keyword) bool {
+	for _, kw := range c.Keywords {
+		if kw == k {
+			return true
+		}
+	}
+	return false
 }
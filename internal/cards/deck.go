@@ -0,0 +1,87 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrDeckInvalid = errors.New("deck violates construction rules")
+
+// DeckRules describes what a legal deck looks like. Zero values mean "no
+// limit", so the zero DeckRules accepts any deck.
+type DeckRules struct {
+	MinSize      int      `json:"min_size,omitempty" yaml:"min_size,omitempty"`
+	MaxSize      int      `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	MaxCopies    int      `json:"max_copies,omitempty" yaml:"max_copies,omitempty"` // per card ID
+	Banned       []string `json:"banned,omitempty" yaml:"banned,omitempty"`         // card IDs not allowed at all
+	Restricted   []string `json:"restricted,omitempty" yaml:"restricted,omitempty"` // card IDs limited to one copy
+	AllowedTypes []Type   `json:"allowed_types,omitempty" yaml:"allowed_types,omitempty"`
+}
+
+// DeckViolation is one way a deck breaks the rules. CardID is empty for
+// rules about the deck as a whole.
+type DeckViolation struct {
+	Rule   string // "min_size", "max_size", "max_copies", "banned", "restricted" or "card_type"
+	CardID string
+	Msg    string
+}
+
+func (v *DeckViolation) Error() string {
+	if v.CardID == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Msg)
+	}
+	return fmt.Sprintf("%s: card %q: %s", v.Rule, v.CardID, v.Msg)
+}
+
+func (v *DeckViolation) Unwrap() error {
+	return ErrDeckInvalid
+}
+
+// ValidateDeck checks deck against the rules and returns every violation,
+// joined; errors.As with *DeckViolation reaches each one. Each card ID is
+// reported at most once per rule.
+func (r *DeckRules) ValidateDeck(deck []CardDef) error {
+	var errs []error
+	fail := func(rule, cardID, format string, args ...any) {
+		errs = append(errs, &DeckViolation{Rule: rule, CardID: cardID, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	if r.MinSize > 0 && len(deck) < r.MinSize {
+		fail("min_size", "", "deck has %d cards, needs at least %d", len(deck), r.MinSize)
+	}
+	if r.MaxSize > 0 && len(deck) > r.MaxSize {
+		fail("max_size", "", "deck has %d cards, allows at most %d", len(deck), r.MaxSize)
+	}
+
+	// Count copies first so each card is reported once, in deck order
+	counts := make(map[string]int)
+	var ids []string
+	types := make(map[string]Type)
+	for _, def := range deck {
+		if counts[def.ID] == 0 {
+			ids = append(ids, def.ID)
+			types[def.ID] = def.Type
+		}
+		counts[def.ID]++
+	}
+
+	for _, id := range ids {
+		n := counts[id]
+
+		if slices.Contains(r.Banned, id) {
+			fail("banned", id, "card is banned")
+			continue
+		}
+		if slices.Contains(r.Restricted, id) && n > 1 {
+			fail("restricted", id, "%d copies, restricted cards allow 1", n)
+		} else if r.MaxCopies > 0 && n > r.MaxCopies {
+			fail("max_copies", id, "%d copies, allows at most %d", n, r.MaxCopies)
+		}
+		if len(r.AllowedTypes) > 0 && !slices.Contains(r.AllowedTypes, types[id]) {
+			fail("card_type", id, "card type %q is not allowed", types[id])
+		}
+	}
+
+	return errors.Join(errs...)
+}
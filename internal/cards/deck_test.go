@@ -0,0 +1,70 @@
+package cards
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deckOf(counts map[string]int, typ Type) []CardDef {
+	var deck []CardDef
+	for id, n := range counts {
+		for range n {
+			deck = append(deck, CardDef{ID: id, Name: id, Type: typ, Health: 1})
+		}
+	}
+	return deck
+}
+
+func TestValidateDeck(t *testing.T) {
+	rules := &DeckRules{
+		MinSize:      4,
+		MaxSize:      8,
+		MaxCopies:    3,
+		Banned:       []string{"forbidden"},
+		Restricted:   []string{"rare"},
+		AllowedTypes: []Type{TypeCreature},
+	}
+
+	tests := []struct {
+		name  string
+		deck  []CardDef
+		rules []string
+	}{
+		{"legal", deckOf(map[string]int{"a": 3, "b": 2, "rare": 1}, TypeCreature), nil},
+		{"too small", deckOf(map[string]int{"a": 3}, TypeCreature), []string{"min_size"}},
+		{"too large", deckOf(map[string]int{"a": 3, "b": 3, "c": 3}, TypeCreature), []string{"max_size"}},
+		{"too many copies", deckOf(map[string]int{"a": 4, "b": 1}, TypeCreature), []string{"max_copies"}},
+		{"banned", deckOf(map[string]int{"a": 3, "forbidden": 1}, TypeCreature), []string{"banned"}},
+		{"restricted", deckOf(map[string]int{"a": 3, "rare": 2}, TypeCreature), []string{"restricted"}},
+		{"wrong type", deckOf(map[string]int{"a": 3, "b": 1}, TypeSpell), []string{"card_type", "card_type"}},
+		{"everything at once", deckOf(map[string]int{"a": 5, "forbidden": 1, "rare": 3}, TypeCreature), []string{"max_size", "banned", "restricted", "max_copies"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.ValidateDeck(tt.deck)
+			if tt.rules == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrDeckInvalid)
+
+			var got []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				var v *DeckViolation
+				require.True(t, errors.As(e, &v))
+				got = append(got, v.Rule)
+			}
+			assert.ElementsMatch(t, tt.rules, got)
+		})
+	}
+}
+
+func TestValidateDeck_ZeroRulesAcceptAnything(t *testing.T) {
+	rules := &DeckRules{}
+	assert.NoError(t, rules.ValidateDeck(deckOf(map[string]int{"a": 40}, TypeSpell)))
+	assert.NoError(t, rules.ValidateDeck(nil))
+}
@@ -0,0 +1,250 @@
+package cards
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DeckCodeVersion is the first byte of every deck code. Bump it when the
+// layout changes.
+const DeckCodeVersion = 1
+
+// maxDeckCards bounds how many cards a code or decklist may expand to, so
+// a few bytes of forged input can't make the parsers allocate without limit.
+const maxDeckCards = 1000
+
+var (
+	ErrDeckCodeMalformed = errors.New("malformed deck code")
+	ErrDeckCodeVersion   = errors.New("unsupported deck code version")
+	ErrDeckCodeChecksum  = errors.New("deck code checksum mismatch")
+	ErrUnknownCard       = errors.New("unknown card")
+	ErrAmbiguousCard     = errors.New("ambiguous card name")
+	ErrDeckTooLarge      = errors.New("too many cards")
+)
+
+// deckEntry is one distinct card in a deck and how many copies it has.
+type deckEntry struct {
+	def   CardDef
+	count int
+}
+
+// groupDeck collapses copies of the same card ID, keeping first-seen order.
+func groupDeck(deck []CardDef) []deckEntry {
+	var entries []deckEntry
+	index := make(map[string]int)
+	for _, def := range deck {
+		if i, ok := index[def.ID]; ok {
+			entries[i].count++
+			continue
+		}
+		index[def.ID] = len(entries)
+		entries = append(entries, deckEntry{def: def, count: 1})
+	}
+	return entries
+}
+
+// EncodeDeck turns a deck into a URL-safe code. The payload is the version
+// byte, the number of distinct cards, then (ID, count) pairs, followed by a
+// CRC-32 of everything before it, all base64url-encoded without padding.
+func EncodeDeck(deck []CardDef) (string, error) {
+	entries := groupDeck(deck)
+
+	buf := []byte{DeckCodeVersion}
+	buf = binary.AppendUvarint(buf, uint64(len(entries)))
+	for _, e := range entries {
+		if e.def.ID == "" {
+			return "", fmt.Errorf("%w: card %q has no ID", ErrUnknownCard, e.def.Name)
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(e.def.ID)))
+		buf = append(buf, e.def.ID...)
+		buf = binary.AppendUvarint(buf, uint64(e.count))
+	}
+	buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// DecodeDeck parses a code made by EncodeDeck and resolves every card ID
+// against known. Copies come back next to each other, in encoded order.
+func DecodeDeck(code string, known []CardDef) ([]CardDef, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(code))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDeckCodeMalformed, err)
+	}
+	if len(buf) < 1+4 {
+		return nil, fmt.Errorf("%w: too short", ErrDeckCodeMalformed)
+	}
+
+	payload, sum := buf[:len(buf)-4], binary.BigEndian.Uint32(buf[len(buf)-4:])
+	if crc32.ChecksumIEEE(payload) != sum {
+		return nil, ErrDeckCodeChecksum
+	}
+	if payload[0] != DeckCodeVersion {
+		return nil, fmt.Errorf("%w: %d", ErrDeckCodeVersion, payload[0])
+	}
+
+	byID := make(map[string]CardDef, len(known))
+	for _, def := range known {
+		byID[def.ID] = def
+	}
+
+	r := payload[1:]
+	readUvarint := func(what string) (uint64, error) {
+		v, n := binary.Uvarint(r)
+		if n <= 0 {
+			return 0, fmt.Errorf("%w: bad %s", ErrDeckCodeMalformed, what)
+		}
+		r = r[n:]
+		return v, nil
+	}
+
+	n, err := readUvarint("entry count")
+	if err != nil {
+		return nil, err
+	}
+	if n > maxDeckCards {
+		return nil, fmt.Errorf("%w: %d entries", ErrDeckCodeMalformed, n)
+	}
+
+	var deck []CardDef
+	var errs []error
+	var total uint64
+	for range n {
+		idLen, err := readUvarint("card ID length")
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(r)) < idLen {
+			return nil, fmt.Errorf("%w: truncated card ID", ErrDeckCodeMalformed)
+		}
+		id := string(r[:idLen])
+		r = r[idLen:]
+
+		count, err := readUvarint("card count")
+		if err != nil {
+			return nil, err
+		}
+		// Unknown cards count too, so the limit never depends on known
+		total += count
+		if count > maxDeckCards || total > maxDeckCards {
+			return nil, fmt.Errorf("%w: more than %d", ErrDeckTooLarge, maxDeckCards)
+		}
+
+		def, ok := byID[id]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownCard, id))
+			continue
+		}
+		for range count {
+			deck = append(deck, def)
+		}
+	}
+	if len(r) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrDeckCodeMalformed, len(r))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return deck, nil
+}
+
+// DecklistError is a problem on one line of a plain-text decklist.
+type DecklistError struct {
+	Line int
+	Err  error
+}
+
+func (e *DecklistError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *DecklistError) Unwrap() error {
+	return e.Err
+}
+
+var decklistLine = regexp.MustCompile(`^(\d+)\s*[xX]\s+(.+)$`)
+
+// ParseDecklist reads one card per line as "2x Grizzly Bear"; a line with
+// only a name counts as one copy. Names are matched against known ignoring
+// case. Blank lines and lines starting with # are skipped. Every bad line is
+// reported.
+func ParseDecklist(text string, known []CardDef) ([]CardDef, error) {
+	byName := make(map[string][]CardDef)
+	for _, def := range known {
+		key := strings.ToLower(def.Name)
+		if slices.ContainsFunc(byName[key], func(d CardDef) bool { return d.ID == def.ID }) {
+			continue
+		}
+		byName[key] = append(byName[key], def)
+	}
+
+	var deck []CardDef
+	var errs []error
+	total := 0
+	fail := func(line int, err error) {
+		errs = append(errs, &DecklistError{Line: line, Err: err})
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		count, name := 1, text
+		if m := decklistLine.FindStringSubmatch(text); m != nil {
+			n, err := strconv.Atoi(m[1])
+			if err != nil || n <= 0 {
+				fail(line, fmt.Errorf("invalid count %q", m[1]))
+				continue
+			}
+			count, name = n, strings.TrimSpace(m[2])
+		}
+		total += count
+		if count > maxDeckCards || total > maxDeckCards {
+			return nil, &DecklistError{Line: line, Err: fmt.Errorf("%w: more than %d", ErrDeckTooLarge, maxDeckCards)}
+		}
+
+		matches := byName[strings.ToLower(name)]
+		if len(matches) == 0 {
+			fail(line, fmt.Errorf("%w: %q", ErrUnknownCard, name))
+			continue
+		}
+		if len(matches) > 1 {
+			fail(line, fmt.Errorf("%w: %q matches %d cards", ErrAmbiguousCard, name, len(matches)))
+			continue
+		}
+
+		for range count {
+			deck = append(deck, matches[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return deck, nil
+}
+
+// FormatDecklist prints a deck as "2x Grizzly Bear" lines, one per distinct
+// card in first-seen order. ParseDecklist reads the result back.
+func FormatDecklist(deck []CardDef) string {
+	var sb strings.Builder
+	for _, e := range groupDeck(deck) {
+		fmt.Fprintf(&sb, "%dx %s\n", e.count, e.def.Name)
+	}
+	return sb.String()
+}
@@ -0,0 +1,172 @@
+package cards
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func knownCards(t *testing.T) []CardDef {
+	t.Helper()
+
+	r := NewRegistry()
+	require.NoError(t, r.LoadFiles("example.json", "testdata/basic.yaml"))
+	return r.All()
+}
+
+func pick(t *testing.T, known []CardDef, counts ...any) []CardDef {
+	t.Helper()
+
+	var deck []CardDef
+	for i := 0; i < len(counts); i += 2 {
+		id, n := counts[i].(string), counts[i+1].(int)
+		idx := -1
+		for j := range known {
+			if known[j].ID == id {
+				idx = j
+			}
+		}
+		require.GreaterOrEqual(t, idx, 0, "unknown card %s", id)
+		for range n {
+			deck = append(deck, known[idx])
+		}
+	}
+	return deck
+}
+
+func TestDeckCode_RoundTrip(t *testing.T) {
+	known := knownCards(t)
+	deck := pick(t, known, "c_grizzly_bear", 3, "s_firebolt", 2, "s_dispel", 1)
+
+	code, err := EncodeDeck(deck)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "+")
+	assert.NotContains(t, code, "/")
+	assert.NotContains(t, code, "=")
+
+	got, err := DecodeDeck(code, known)
+	require.NoError(t, err)
+	assert.Equal(t, deck, got)
+}
+
+func TestDeckCode_GroupsCopies(t *testing.T) {
+	known := knownCards(t)
+	interleaved := pick(t, known, "s_firebolt", 1, "c_grizzly_bear", 1, "s_firebolt", 1)
+
+	code, err := EncodeDeck(interleaved)
+	require.NoError(t, err)
+
+	got, err := DecodeDeck(code, known)
+	require.NoError(t, err)
+	assert.Equal(t, pick(t, known, "s_firebolt", 2, "c_grizzly_bear", 1), got)
+}
+
+func TestDeckCode_Errors(t *testing.T) {
+	known := knownCards(t)
+	code, err := EncodeDeck(pick(t, known, "c_grizzly_bear", 2))
+	require.NoError(t, err)
+	raw, err := base64.RawURLEncoding.DecodeString(code)
+	require.NoError(t, err)
+
+	corrupt := append([]byte(nil), raw...)
+	corrupt[3] ^= 0xff
+
+	t.Run("checksum", func(t *testing.T) {
+		_, err := DecodeDeck(base64.RawURLEncoding.EncodeToString(corrupt), known)
+		assert.ErrorIs(t, err, ErrDeckCodeChecksum)
+	})
+
+	t.Run("not base64", func(t *testing.T) {
+		_, err := DecodeDeck("not a deck code!", known)
+		assert.ErrorIs(t, err, ErrDeckCodeMalformed)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := DecodeDeck("AQ", known)
+		assert.ErrorIs(t, err, ErrDeckCodeMalformed)
+	})
+
+	t.Run("unknown card", func(t *testing.T) {
+		_, err := DecodeDeck(code, nil)
+		assert.ErrorIs(t, err, ErrUnknownCard)
+		assert.Contains(t, err.Error(), "c_grizzly_bear")
+	})
+
+	t.Run("too many cards in total", func(t *testing.T) {
+		// Each entry is under the limit on its own
+		big, err := EncodeDeck(pick(t, known, "c_grizzly_bear", 600, "s_firebolt", 600))
+		require.NoError(t, err)
+		_, err = DecodeDeck(big, known)
+		assert.ErrorIs(t, err, ErrDeckTooLarge)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		future, err := EncodeDeck(nil)
+		require.NoError(t, err)
+		buf, _ := base64.RawURLEncoding.DecodeString(future)
+		buf[0] = DeckCodeVersion + 1
+		payload := buf[:len(buf)-4]
+		buf = binary.BigEndian.AppendUint32(payload[:len(payload):len(payload)], crc32.ChecksumIEEE(payload))
+
+		_, err = DecodeDeck(base64.RawURLEncoding.EncodeToString(buf), known)
+		assert.ErrorIs(t, err, ErrDeckCodeVersion)
+	})
+}
+
+func TestDecklist_RoundTrip(t *testing.T) {
+	known := knownCards(t)
+	deck := pick(t, known, "c_grizzly_bear", 2, "s_firebolt", 3, "c_field_medic", 1)
+
+	text := FormatDecklist(deck)
+	assert.Equal(t, "2x Grizzly Bear\n3x Fire Bolt\n1x Field Medic\n", text)
+
+	got, err := ParseDecklist(text, known)
+	require.NoError(t, err)
+	assert.Equal(t, deck, got)
+}
+
+func TestParseDecklist(t *testing.T) {
+	known := knownCards(t)
+
+	got, err := ParseDecklist(`
+# aggro
+2x grizzly bear
+3 X Fire Bolt
+Dispel
+`, known)
+	require.NoError(t, err)
+	assert.Equal(t, pick(t, known, "c_grizzly_bear", 2, "s_firebolt", 3, "s_dispel", 1), got)
+}
+
+func TestParseDecklist_Errors(t *testing.T) {
+	known := append(knownCards(t), CardDef{ID: "c_bear_alt", Name: "Grizzly Bear", Type: TypeCreature, Health: 1})
+
+	_, err := ParseDecklist("2x Grizzly Bear\n1x Fire Bolt\n4x Mystery Card\n0x Dispel\n", known)
+	require.Error(t, err)
+
+	var lines []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var de *DecklistError
+		require.True(t, errors.As(e, &de))
+		lines = append(lines, de.Line)
+	}
+	assert.Equal(t, []int{1, 3, 4}, lines)
+	assert.ErrorIs(t, err, ErrAmbiguousCard)
+	assert.ErrorIs(t, err, ErrUnknownCard)
+	assert.True(t, strings.Contains(err.Error(), `line 3: unknown card: "Mystery Card"`), err.Error())
+}
+
+func TestParseDecklist_TooManyCards(t *testing.T) {
+	_, err := ParseDecklist("600x Grizzly Bear\n600x Fire Bolt\n1x Dispel\n", knownCards(t))
+	assert.ErrorIs(t, err, ErrDeckTooLarge)
+
+	var de *DecklistError
+	require.ErrorAs(t, err, &de)
+	assert.Equal(t, 2, de.Line)
+}
@@ -0,0 +1,305 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidExpr = errors.New("invalid expression")
+
+// Quantity is a game value an expression can refer to. The engine works out
+// its value when the effect resolves; quantities that don't apply, such as
+// target.life for a creature target, are 0.
+type Quantity string
+
+const (
+	QuantityTurn           Quantity = "turn"
+	QuantitySelfLife       Quantity = "self.life" // self is the player the effect belongs to
+	QuantitySelfEnergy     Quantity = "self.energy"
+	QuantitySelfMaxEnergy  Quantity = "self.max_energy"
+	QuantityEnemyLife      Quantity = "enemy.life"
+	QuantityEnemyEnergy    Quantity = "enemy.energy"
+	QuantityEnemyMaxEnergy Quantity = "enemy.max_energy"
+	QuantitySourceAttack   Quantity = "source.attack" // source is the card the effect comes from
+	QuantitySourceHealth   Quantity = "source.health"
+	QuantityTargetAttack   Quantity = "target.attack"
+	QuantityTargetHealth   Quantity = "target.health"
+	QuantityTargetLife     Quantity = "target.life"
+
+	QuantityAllyCreatures  Quantity = "count(ally_creatures)"
+	QuantityEnemyCreatures Quantity = "count(enemy_creatures)"
+	QuantityAllCreatures   Quantity = "count(all_creatures)"
+	QuantityAllyHand       Quantity = "count(ally_hand)" // also written cards_in_hand
+	QuantityEnemyHand      Quantity = "count(enemy_hand)"
+	QuantityAllyGraveyard  Quantity = "count(ally_graveyard)"
+	QuantityEnemyGraveyard Quantity = "count(enemy_graveyard)"
+	QuantityAllyDeck       Quantity = "count(ally_deck)"
+	QuantityEnemyDeck      Quantity = "count(enemy_deck)"
+)
+
+var (
+	knownQuantities = map[Quantity]bool{
+		QuantityTurn:           true,
+		QuantitySelfLife:       true,
+		QuantitySelfEnergy:     true,
+		QuantitySelfMaxEnergy:  true,
+		QuantityEnemyLife:      true,
+		QuantityEnemyEnergy:    true,
+		QuantityEnemyMaxEnergy: true,
+		QuantitySourceAttack:   true,
+		QuantitySourceHealth:   true,
+		QuantityTargetAttack:   true,
+		QuantityTargetHealth:   true,
+		QuantityTargetLife:     true,
+		QuantityAllyCreatures:  true,
+		QuantityEnemyCreatures: true,
+		QuantityAllCreatures:   true,
+		QuantityAllyHand:       true,
+		QuantityEnemyHand:      true,
+		QuantityAllyGraveyard:  true,
+		QuantityEnemyGraveyard: true,
+		QuantityAllyDeck:       true,
+		QuantityEnemyDeck:      true,
+	}
+
+	quantityAliases = map[string]Quantity{
+		"cards_in_hand": QuantityAllyHand,
+	}
+
+	comparisons = map[string]bool{">=": true, "<=": true, ">": true, "<": true, "==": true, "!=": true}
+)
+
+// Expr is a parsed value or condition expression. A leaf (empty Op) holds a
+// number or a quantity; other nodes combine Left and Right with Op, one of
+// + - * or a comparison, "and" or "or".
+type Expr struct {
+	Op       string
+	Left     *Expr
+	Right    *Expr
+	Number   int
+	Quantity Quantity
+}
+
+// ParseValue parses an expression such as "2 + count(ally_creatures)".
+func ParseValue(s string) (*Expr, error) {
+	return parse(s, (*parser).sum)
+}
+
+// ParseCondition parses comparisons joined by "and" and "or", such as
+// "count(ally_creatures) >= 3 and self.life < 10". "and" binds tighter.
+func ParseCondition(s string) (*Expr, error) {
+	return parse(s, (*parser).or)
+}
+
+// Eval computes the expression, looking quantities up with quantity.
+// Conditions evaluate to 1 when true and 0 when false.
+func (x *Expr) Eval(quantity func(Quantity) int) int {
+	if x.Op == "" {
+		if x.Quantity != "" {
+			return quantity(x.Quantity)
+		}
+		return x.Number
+	}
+
+	l, r := x.Left.Eval(quantity), x.Right.Eval(quantity)
+	var b bool
+	switch x.Op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case ">=":
+		b = l >= r
+	case "<=":
+		b = l <= r
+	case ">":
+		b = l > r
+	case "<":
+		b = l < r
+	case "==":
+		b = l == r
+	case "!=":
+		b = l != r
+	case "and":
+		b = l != 0 && r != 0
+	case "or":
+		b = l != 0 || r != 0
+	}
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Holds reports whether a condition is true.
+func (x *Expr) Holds(quantity func(Quantity) int) bool {
+	return x.Eval(quantity) != 0
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func parse(s string, top func(*parser) (*Expr, error)) (*Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidExpr)
+	}
+
+	p := &parser{tokens: tokens}
+	x, err := top(p)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpr, p.tokens[p.pos])
+	}
+	return x, nil
+}
+
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("()+-*", c):
+			tokens = append(tokens, string(c))
+			i++
+		case strings.ContainsRune("<>=!", c):
+			if i+1 < len(s) && s[i+1] == '=' {
+				tokens = append(tokens, s[i:i+2])
+				i += 2
+			} else {
+				tokens = append(tokens, string(c))
+				i++
+			}
+		case c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			j := i
+			for j < len(s) && (s[j] == '_' || s[j] == '.' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpr, c)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) or() (*Expr, error) {
+	return p.chain(func(op string) bool { return op == "or" }, (*parser).and)
+}
+
+func (p *parser) and() (*Expr, error) {
+	return p.chain(func(op string) bool { return op == "and" }, (*parser).comparison)
+}
+
+func (p *parser) comparison() (*Expr, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if !comparisons[op] {
+		return nil, fmt.Errorf("%w: expected a comparison, got %q", ErrInvalidExpr, op)
+	}
+	right, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{Op: op, Left: left, Right: right}, nil
+}
+
+func (p *parser) sum() (*Expr, error) {
+	return p.chain(func(op string) bool { return op == "+" || op == "-" }, (*parser).term)
+}
+
+func (p *parser) term() (*Expr, error) {
+	return p.chain(func(op string) bool { return op == "*" }, (*parser).factor)
+}
+
+// chain parses operands joined left to right by operators matching isOp.
+func (p *parser) chain(isOp func(string) bool, operand func(*parser) (*Expr, error)) (*Expr, error) {
+	left, err := operand(p)
+	if err != nil {
+		return nil, err
+	}
+	for isOp(p.peek()) {
+		op := p.next()
+		right, err := operand(p)
+		if err != nil {
+			return nil, err
+		}
+		left = &Expr{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) factor() (*Expr, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("%w: unexpected end", ErrInvalidExpr)
+
+	case t == "(":
+		x, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("%w: missing )", ErrInvalidExpr)
+		}
+		return x, nil
+
+	case unicode.IsDigit(rune(t[0])):
+		n, err := strconv.Atoi(t)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad number %q", ErrInvalidExpr, t)
+		}
+		return &Expr{Number: n}, nil
+	}
+
+	name := t
+	if p.peek() == "(" {
+		p.next()
+		arg := p.next()
+		if p.next() != ")" {
+			return nil, fmt.Errorf("%w: missing ) after %s(", ErrInvalidExpr, t)
+		}
+		name = t + "(" + arg + ")"
+	}
+
+	q, ok := quantityAliases[name]
+	if !ok {
+		q = Quantity(name)
+	}
+	if !knownQuantities[q] {
+		return nil, fmt.Errorf("%w: unknown quantity %q", ErrInvalidExpr, name)
+	}
+	return &Expr{Quantity: q}, nil
+}
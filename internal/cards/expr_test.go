@@ -0,0 +1,93 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue(t *testing.T) {
+	quantities := map[Quantity]int{
+		QuantityAllyCreatures: 3,
+		QuantityAllyHand:      5,
+		QuantitySelfLife:      12,
+		QuantityTargetAttack:  4,
+	}
+	lookup := func(q Quantity) int { return quantities[q] }
+
+	tests := []struct {
+		expr string
+		want int
+	}{
+		{"7", 7},
+		{"cards_in_hand", 5},
+		{"count(ally_hand)", 5},
+		{"count( ally_creatures )", 3},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"self.life - target.attack - 1", 7},
+		{"2 * count(ally_creatures)", 6},
+		{"enemy.life", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			x, err := ParseValue(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, x.Eval(lookup))
+		})
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	quantities := map[Quantity]int{QuantityAllyCreatures: 3, QuantitySelfLife: 12}
+	lookup := func(q Quantity) int { return quantities[q] }
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"count(ally_creatures) >= 3", true},
+		{"count(ally_creatures) > 3", false},
+		{"self.life == 12", true},
+		{"self.life != 12", false},
+		{"self.life < 10 or count(ally_creatures) >= 3", true},
+		{"self.life < 10 and count(ally_creatures) >= 3", false},
+		{"1 > 2 and 1 > 2 or 2 > 1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			x, err := ParseCondition(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, x.Holds(lookup))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) (*Expr, error)
+		expr  string
+	}{
+		{"empty", ParseValue, ""},
+		{"unknown quantity", ParseValue, "self.mana"},
+		{"unknown count", ParseValue, "count(dragons)"},
+		{"trailing operator", ParseValue, "1 +"},
+		{"unclosed paren", ParseValue, "(1 + 2"},
+		{"comparison in value", ParseValue, "1 > 2"},
+		{"bad character", ParseValue, "1 / 2"},
+		{"value as condition", ParseCondition, "self.life"},
+		{"single equals", ParseCondition, "self.life = 3"},
+		{"dangling and", ParseCondition, "turn > 1 and"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.parse(tt.expr)
+			assert.ErrorIs(t, err, ErrInvalidExpr)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package cards
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported card set format")
+	ErrMalformedCardSet  = errors.New("malformed card set")
+)
+
+// Registry holds every card definition loaded from card-set files, indexed
+// by ID. A card set is a JSON or YAML list of CardDef.
+type Registry struct {
+	defs   map[string]*CardDef
+	order  []string          // IDs in load order
+	source map[string]string // ID -> file it was loaded from
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		defs:   make(map[string]*CardDef),
+		source: make(map[string]string),
+	}
+}
+
+// LoadFiles loads each card-set file in turn, stopping at the first file
+// that fails.
+func (r *Registry) LoadFiles(paths ...string) error {
+	for _, path := range paths {
+		if err := r.LoadFile(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile loads a card set, picking JSON or YAML from the file extension.
+func (r *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.Load(path, data)
+}
+
+// Load parses a card set named name (used for the format and in errors) and
+// adds its cards. Every problem in the set is reported; if there is any, no
+// card from the set is added.
+func (r *Registry) Load(name string, data []byte) error {
+	defs, err := decodeCardSet(name, data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	seen := make(map[string]int, len(defs))
+	for i := range defs {
+		def := &defs[i]
+
+		verrs := def.validate()
+		if def.ID != "" {
+			if j, ok := seen[def.ID]; ok {
+				verrs = append(verrs, &ValidationError{CardID: def.ID, Field: "id", Msg: fmt.Sprintf("duplicate of card[%d]", j)})
+			} else if src, ok := r.source[def.ID]; ok {
+				verrs = append(verrs, &ValidationError{CardID: def.ID, Field: "id", Msg: fmt.Sprintf("already loaded from %s", src)})
+			}
+			seen[def.ID] = i
+		}
+
+		for _, e := range verrs {
+			e.File, e.Index = name, i
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for i := range defs {
+		def := &defs[i]
+		r.defs[def.ID] = def
+		r.source[def.ID] = name
+		r.order = append(r.order, def.ID)
+	}
+	return nil
+}
+
+func decodeCardSet(name string, data []byte) ([]CardDef, error) {
+	var defs []CardDef
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&defs); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrMalformedCardSet, name, err)
+		}
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&defs); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s: %v", ErrMalformedCardSet, name, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, name)
+	}
+
+	return defs, nil
+}
+
+// Get returns the definition with the given ID.
+func (r *Registry) Get(id string) (*CardDef, bool) {
+	def, ok := r.defs[id]
+	return def, ok
+}
+
+// Len returns the number of loaded cards.
+func (r *Registry) Len() int {
+	return len(r.order)
+}
+
+// All returns a copy of every loaded definition in load order.
+func (r *Registry) All() []CardDef {
+	out := make([]CardDef, 0, len(r.order))
+	for _, id := range r.order {
+		out = append(out, *r.defs[id])
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+package cards
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationErrors(t *testing.T, err error) []*ValidationError {
+	t.Helper()
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok, "expected joined errors, got %v", err)
+
+	var out []*ValidationError
+	for _, e := range joined.Unwrap() {
+		var ve *ValidationError
+		require.True(t, errors.As(e, &ve), "unexpected error %v", e)
+		out = append(out, ve)
+	}
+	return out
+}
+
+func TestRegistry_LoadFiles(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.LoadFiles("example.json", "testdata/basic.yaml"))
+
+	assert.Equal(t, 5, r.Len())
+
+	bolt, ok := r.Get("s_firebolt")
+	require.True(t, ok)
+	require.Len(t, bolt.Effects, 1)
+	assert.Equal(t, EffectDamage, bolt.Effects[0].Kind)
+
+	medic, ok := r.Get("c_field_medic")
+	require.True(t, ok)
+	require.Len(t, medic.Abilities, 1)
+	assert.Equal(t, TriggerOnPlay, medic.Abilities[0].Trigger)
+
+	dispel, ok := r.Get("s_dispel")
+	require.True(t, ok)
+	assert.True(t, dispel.IsInstant())
+
+	shield, _ := r.Get("c_shieldbearer")
+	assert.True(t, shield.HasKeyword(KeywordTaunt))
+
+	_, ok = r.Get("nope")
+	assert.False(t, ok)
+
+	all := r.All()
+	require.Len(t, all, 5)
+	assert.Equal(t, "c_grizzly_bear", all[0].ID)
+	assert.Equal(t, "s_dispel", all[4].ID)
+}
+
+func TestRegistry_ReportsEveryInvalidCard(t *testing.T) {
+	r := NewRegistry()
+	err := r.LoadFile("testdata/invalid.json")
+	require.ErrorIs(t, err, ErrInvalidCard)
+
+	errs := validationErrors(t, err)
+
+	type key struct {
+		index int
+		field string
+	}
+	got := make(map[key]string)
+	for _, e := range errs {
+		assert.Equal(t, "testdata/invalid.json", e.File)
+		got[key{e.Index, e.Field}] = e.CardID
+	}
+
+	assert.Equal(t, map[key]string{
+		{0, "effects"}:           "s_empty",
+		{1, "health"}:            "c_ghost",
+		{2, "effects[0].kind"}:   "s_weird",
+		{2, "effects[0].target"}: "s_weird",
+		{3, "id"}:                "c_ghost",
+	}, got)
+
+	assert.Equal(t, 0, r.Len(), "a failing set adds no cards")
+}
+
+func TestRegistry_DuplicateAcrossFiles(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.LoadFile("example.json"))
+
+	err := r.Load("more.yaml", []byte("- {id: c_grizzly_bear, name: Bear, type: creature, cost: 2, attack: 2, health: 2}\n"))
+	require.ErrorIs(t, err, ErrInvalidCard)
+	assert.Contains(t, err.Error(), `more.yaml: card[0] "c_grizzly_bear": id: already loaded from example.json`)
+}
+
+func TestRegistry_MalformedSets(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		err  error
+	}{
+		{"unknown json field", "set.json", `[{"id":"x","name":"X","type":"spell","cost":1,"spell_effect":{}}]`, ErrMalformedCardSet},
+		{"unknown yaml field", "set.yaml", "- {id: x, name: X, type: spell, cost: 1, spell_effect: {}}\n", ErrMalformedCardSet},
+		{"not a list", "set.json", `{"id":"x"}`, ErrMalformedCardSet},
+		{"unknown extension", "set.toml", ``, ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRegistry().Load(tt.file, []byte(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCardDef_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		def   CardDef
+		field string
+	}{
+		{"valid creature", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1}, ""},
+		{"missing id", CardDef{Name: "C", Type: TypeCreature, Health: 1}, "id"},
+		{"unknown type", CardDef{ID: "x", Name: "X", Type: "artifact"}, "type"},
+		{"negative cost", CardDef{ID: "c", Name: "C", Type: TypeCreature, Cost: -1, Health: 1}, "cost"},
+		{"unknown speed", CardDef{ID: "s", Name: "S", Type: TypeSpell, Speed: "fast", Effects: []Effect{{Kind: EffectDamage}}}, "speed"},
+		{"unknown keyword", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Keywords: []Keyword{"flying"}}, "keywords[0]"},
+		{"unknown trigger", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Abilities: []Ability{{Trigger: "on_attack", Effects: []Effect{{Kind: EffectHeal}}}}}, "abilities[0].trigger"},
+		{"ability target", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Abilities: []Ability{{Trigger: TriggerOnPlay, Effects: []Effect{{Kind: EffectHeal, Target: "friend"}}}}}, "abilities[0].effects[0].target"},
+		{"ability with a chosen target", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Abilities: []Ability{{Trigger: TriggerOnDeath, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetEnemyCreature}}}}}, "abilities[0].effects[0].target"},
+		{"valid ability", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Abilities: []Ability{{Trigger: TriggerOnDeath, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetRandomEnemyCreature}}}}}, ""},
+		{"valid choose one", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectChooseOne, Target: TargetSelfPlayer, Choices: []Effect{
+			{Kind: EffectDrawCards, Amount: 1, Target: TargetSelfPlayer},
+			{Kind: EffectDamage, Amount: 2, Target: TargetEnemyPlayer},
+		}}}}, ""},
+		{"too few choices", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectChooseOne, Target: TargetSelfPlayer, Choices: []Effect{{Kind: EffectHeal, Target: TargetSelfPlayer}}}}}, "effects[0].choices"},
+		{"choice needs a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectChooseOne, Target: TargetSelfPlayer, Choices: []Effect{
+			{Kind: EffectHeal, Target: TargetSelfPlayer},
+			{Kind: EffectDamage, Target: TargetAnyCreature},
+		}}}}, "effects[0].choices[1].target"},
+		{"valid transform", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTransform, Target: TargetAnyCreature, Into: &CardDef{ID: "sheep", Name: "Sheep", Type: TypeCreature, Attack: 1, Health: 1}}}}, ""},
+		{"transform without into", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTransform, Target: TargetAnyCreature}}}, "effects[0].into"},
+		{"transform into a spell", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTransform, Target: TargetAnyCreature, Into: &CardDef{ID: "x", Name: "X", Type: TypeSpell, Effects: []Effect{{Kind: EffectHeal}}}}}}, "effects[0].into.type"},
+		{"invalid into", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTransform, Target: TargetAnyCreature, Into: &CardDef{ID: "x", Name: "X", Type: TypeCreature}}}}, "effects[0].into.health"},
+		{"destroy a player", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDestroy, Target: TargetEnemyPlayer}}}, "effects[0].target"},
+		{"valid summon", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectSummon, Amount: 2, CardID: "t_wolf", Target: TargetSelfPlayer}}}, ""},
+		{"summon without card", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectSummon, Target: TargetSelfPlayer}}}, "effects[0].card_id"},
+		{"summon onto a creature", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectSummon, CardID: "t_wolf", Target: TargetAnyCreature}}}, "effects[0].target"},
+		{"valid resurrect", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectResurrect, Target: TargetEnemyGraveyard}}}, ""},
+		{"recycle from the board", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectRecycle, Target: TargetAllyCreature}}}, "effects[0].target"},
+		{"valid counter", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectCounter, Target: TargetStackSpell}}}, ""},
+		{"counter a creature", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectCounter, Target: TargetAnyCreature}}}, "effects[0].target"},
+		{"damage a spell on the stack", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetStackSpell}}}, "effects[0].target"},
+		{"damage to a graveyard", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetAllyGraveyard}}}, "effects[0].target"},
+		{"valid tutor", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTutor, Target: TargetSelfPlayer, Filter: &CardFilter{Type: TypeCreature}}}}, ""},
+		{"tutor unknown keyword", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectTutor, Target: TargetSelfPlayer, Filter: &CardFilter{Keyword: "flying"}}}}, "effects[0].filter.keyword"},
+		{"filter on other kinds", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectMill, Amount: 2, Target: TargetEnemyPlayer, Filter: &CardFilter{}}}}, "effects[0].filter"},
+		{"random mill", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectMill, Amount: 2, Target: TargetEnemyPlayer, Random: true}}}, "effects[0].random"},
+		{"scry a creature", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectScry, Amount: 2, Target: TargetAnyCreature}}}, "effects[0].target"},
+		{"damage without a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetNone}}}, "effects[0].target"},
+		{"damage with an empty target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1}}}, "effects[0].target"},
+		{"heal without a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectHeal, Amount: 1, Target: TargetNone}}}, "effects[0].target"},
+		{"draw with an empty target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDrawCards, Amount: 1}}}, "effects[0].target"},
+		{"draw for a creature", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDrawCards, Amount: 1, Target: TargetAnyCreature}}}, "effects[0].target"},
+		{"buff without a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectBuffStatsTemp, BuffAttack: 1, Target: TargetNone}}}, "effects[0].target"},
+		{"destroy with an empty target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDestroy}}}, "effects[0].target"},
+		{"silence without a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectSilence, Target: TargetNone}}}, "effects[0].target"},
+		{"freeze with an empty target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectFreeze}}}, "effects[0].target"},
+		{"mill without a target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectMill, Amount: 1, Target: TargetNone}}}, "effects[0].target"},
+		{"valid area damage", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetAllEnemyCharacters}}}, ""},
+		{"area destroy", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDestroy, Target: TargetAllCreatures}}}, ""},
+		{"buff players", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectBuffStatsPerm, BuffAttack: 1, Target: TargetAllAllyCharacters}}}, "effects[0].target"},
+		{"up to n without max", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Target: TargetUpToN}}}, "effects[0].max_targets"},
+		{"max targets on single target", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, MaxTargets: 2, Target: TargetAnyCreature}}}, "effects[0].max_targets"},
+		{"valid condition and value", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Condition: "count(ally_creatures) >= 3", Value: "cards_in_hand", Target: TargetEnemyPlayer}}}, ""},
+		{"unknown quantity", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Value: "count(ally_dragons)", Target: TargetEnemyPlayer}}}, "effects[0].value"},
+		{"condition without comparison", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDrawCards, Amount: 1, Condition: "self.life", Target: TargetSelfPlayer}}}, "effects[0].condition"},
+		{"value and amount", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDamage, Amount: 1, Value: "turn", Target: TargetEnemyPlayer}}}, "effects[0].value"},
+		{"value on a buff", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectBuffStatsPerm, Value: "turn", Target: TargetAnyCreature}}}, "effects[0].value"},
+		{"valid aura", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Auras: []Aura{{Scope: AuraOtherAllyCreatures, BuffAttack: 1, BuffHealth: 1}}}, ""},
+		{"unknown aura scope", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Auras: []Aura{{Scope: "adjacent", BuffAttack: 1}}}, "auras[0].scope"},
+		{"aura without buff", CardDef{ID: "c", Name: "C", Type: TypeCreature, Health: 1, Auras: []Aura{{Scope: AuraAllyCreatures}}}, "auras[0]"},
+		{"aura on a spell", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectDrawCards, Amount: 1, Target: TargetSelfPlayer}}, Auras: []Aura{{Scope: AuraAllyCreatures, BuffAttack: 1}}}, "auras"},
+		{"choices on other kinds", CardDef{ID: "s", Name: "S", Type: TypeSpell, Effects: []Effect{{Kind: EffectHeal, Target: TargetSelfPlayer, Choices: []Effect{{Kind: EffectHeal}}}}}, "effects[0].choices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tt.field, ve.Field)
+		})
+	}
+}
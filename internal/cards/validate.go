@@ -0,0 +1,391 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidCard = errors.New("invalid card definition")
+
+// ValidationError points at a single problem in a card definition. File and
+// Index are filled in when the card was loaded from a card-set file.
+type ValidationError struct {
+	File   string
+	Index  int // position of the card in its file
+	CardID string
+	Field  string // e.g. "health" or "effects[0].target"
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	loc := fmt.Sprintf("card %q", e.CardID)
+	if e.File != "" {
+		loc = fmt.Sprintf("%s: card[%d] %q", e.File, e.Index, e.CardID)
+	}
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", loc, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", loc, e.Field, e.Msg)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidCard
+}
+
+var (
+	knownTypes = map[Type]bool{TypeCreature: true, TypeSpell: true}
+
+	knownSpeeds = map[Speed]bool{"": true, SpeedNormal: true, SpeedInstant: true}
+
+	knownEffectKinds = map[EffectKind]bool{
+		EffectDamage:          true,
+		EffectHeal:            true,
+		EffectDrawCards:       true,
+		EffectBuffStatsPerm:   true,
+		EffectBuffStatsTemp:   true,
+		EffectCounter:         true,
+		EffectChooseOne:       true,
+		EffectDiscover:        true,
+		EffectDestroy:         true,
+		EffectReturnToHand:    true,
+		EffectSilence:         true,
+		EffectFreeze:          true,
+		EffectTransform:       true,
+		EffectSummon:          true,
+		EffectResurrect:       true,
+		EffectRecycle:         true,
+		EffectShuffleIntoDeck: true,
+		EffectMill:            true,
+		EffectDiscard:         true,
+		EffectScry:            true,
+		EffectTutor:           true,
+		EffectShuffle:         true,
+	}
+
+	// amountEffects use Amount, which Value can set instead.
+	amountEffects = map[EffectKind]bool{
+		EffectDamage:    true,
+		EffectHeal:      true,
+		EffectDrawCards: true,
+		EffectDiscover:  true,
+		EffectSummon:    true,
+		EffectMill:      true,
+		EffectDiscard:   true,
+		EffectScry:      true,
+		EffectTutor:     true,
+	}
+
+	playerTargets = map[TargetKind]bool{
+		TargetEnemyPlayer: true,
+		TargetSelfPlayer:  true,
+	}
+
+	creatureTargets = map[TargetKind]bool{
+		TargetAnyCreature:         true,
+		TargetEnemyCreature:       true,
+		TargetAllyCreature:        true,
+		TargetSelf:                true,
+		TargetAllEnemyCreatures:   true,
+		TargetAllAllyCreatures:    true,
+		TargetAllCreatures:        true,
+		TargetRandomEnemyCreature: true,
+		TargetUpToN:               true,
+	}
+
+	// characterTargets hit players as well as creatures.
+	characterTargets = map[TargetKind]bool{
+		TargetAllEnemyCharacters: true,
+		TargetAllAllyCharacters:  true,
+		TargetAllCharacters:      true,
+	}
+
+	graveyardTargets = map[TargetKind]bool{
+		TargetAllyGraveyard:  true,
+		TargetEnemyGraveyard: true,
+	}
+
+	// effectTargets lists what each effect kind can target. Every effect acts
+	// on something, so none of them takes "none" or an empty target.
+	effectTargets = map[EffectKind]map[TargetKind]bool{
+		EffectDamage:          union(playerTargets, creatureTargets, characterTargets),
+		EffectHeal:            union(playerTargets, creatureTargets, characterTargets),
+		EffectDrawCards:       playerTargets,
+		EffectBuffStatsPerm:   creatureTargets,
+		EffectBuffStatsTemp:   creatureTargets,
+		EffectCounter:         {TargetStackSpell: true},
+		EffectChooseOne:       playerTargets,
+		EffectDiscover:        playerTargets,
+		EffectDestroy:         creatureTargets,
+		EffectReturnToHand:    creatureTargets,
+		EffectSilence:         creatureTargets,
+		EffectFreeze:          creatureTargets,
+		EffectTransform:       creatureTargets,
+		EffectSummon:          playerTargets,
+		EffectResurrect:       graveyardTargets,
+		EffectRecycle:         graveyardTargets,
+		EffectShuffleIntoDeck: graveyardTargets,
+		EffectMill:            playerTargets,
+		EffectDiscard:         playerTargets,
+		EffectScry:            playerTargets,
+		EffectTutor:           playerTargets,
+		EffectShuffle:         playerTargets,
+	}
+
+	knownTargetKinds = map[TargetKind]bool{
+		"":                        true,
+		TargetNone:                true,
+		TargetEnemyPlayer:         true,
+		TargetSelfPlayer:          true,
+		TargetAnyCreature:         true,
+		TargetEnemyCreature:       true,
+		TargetAllyCreature:        true,
+		TargetSelf:                true,
+		TargetStackSpell:          true,
+		TargetAllyGraveyard:       true,
+		TargetEnemyGraveyard:      true,
+		TargetAllEnemyCreatures:   true,
+		TargetAllAllyCreatures:    true,
+		TargetAllCreatures:        true,
+		TargetAllEnemyCharacters:  true,
+		TargetAllAllyCharacters:   true,
+		TargetAllCharacters:       true,
+		TargetRandomEnemyCreature: true,
+		TargetUpToN:               true,
+	}
+
+	// choiceTargets are the targets the engine can pick on its own, which is
+	// all a choose_one option or an ability may use.
+	choiceTargets = map[TargetKind]bool{
+		TargetSelf:                true,
+		TargetSelfPlayer:          true,
+		TargetEnemyPlayer:         true,
+		TargetAllEnemyCreatures:   true,
+		TargetAllAllyCreatures:    true,
+		TargetAllCreatures:        true,
+		TargetAllEnemyCharacters:  true,
+		TargetAllAllyCharacters:   true,
+		TargetAllCharacters:       true,
+		TargetRandomEnemyCreature: true,
+	}
+
+	knownTriggers = map[Trigger]bool{
+		TriggerOnPlay:      true,
+		TriggerOnDeath:     true,
+		TriggerOnTurnStart: true,
+		TriggerOnTurnEnd:   true,
+		TriggerOnDamaged:   true,
+		TriggerOnDraw:      true,
+	}
+
+	knownAuraScopes = map[AuraScope]bool{
+		AuraOtherAllyCreatures: true,
+		AuraAllyCreatures:      true,
+		AuraEnemyCreatures:     true,
+		AuraAllCreatures:       true,
+	}
+
+	knownKeywords = map[Keyword]bool{
+		KeywordHaste:        true,
+		KeywordDivineShield: true,
+		KeywordLifesteal:    true,
+		KeywordPoisonous:    true,
+		KeywordTaunt:        true,
+		KeywordVigilance:    true,
+	}
+)
+
+// Validate checks the definition on its own and returns every problem found,
+// joined; errors.As with *ValidationError reaches each one. Rules that span
+// several cards, such as unique IDs, are checked by Registry.
+func (c *CardDef) Validate() error {
+	var errs []error
+	for _, e := range c.validate() {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+func (c *CardDef) validate() []*ValidationError {
+	var errs []*ValidationError
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, &ValidationError{CardID: c.ID, Field: field, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	if c.ID == "" {
+		fail("id", "must not be empty")
+	}
+	if c.Name == "" {
+		fail("name", "must not be empty")
+	}
+	if !knownTypes[c.Type] {
+		fail("type", "unknown card type %q", c.Type)
+	}
+	if c.Cost < 0 {
+		fail("cost", "must not be negative, got %d", c.Cost)
+	}
+	if !knownSpeeds[c.Speed] {
+		fail("speed", "unknown speed %q", c.Speed)
+	}
+
+	switch c.Type {
+	case TypeCreature:
+		if c.Health <= 0 {
+			fail("health", "creature needs positive health, got %d", c.Health)
+		}
+		if c.Attack < 0 {
+			fail("attack", "must not be negative, got %d", c.Attack)
+		}
+	case TypeSpell:
+		if len(c.Effects) == 0 {
+			fail("effects", "spell needs at least one effect")
+		}
+	}
+
+	for i, e := range c.Effects {
+		validateEffect(fmt.Sprintf("effects[%d]", i), e, fail)
+	}
+
+	for i, kw := range c.Keywords {
+		if !knownKeywords[kw] {
+			fail(fmt.Sprintf("keywords[%d]", i), "unknown keyword %q", kw)
+		}
+	}
+
+	for i, a := range c.Abilities {
+		field := fmt.Sprintf("abilities[%d]", i)
+		if !knownTriggers[a.Trigger] {
+			fail(field+".trigger", "unknown trigger %q", a.Trigger)
+		}
+		if len(a.Effects) == 0 {
+			fail(field+".effects", "ability needs at least one effect")
+		}
+		for j, e := range a.Effects {
+			effect := fmt.Sprintf("%s.effects[%d]", field, j)
+			validateEffect(effect, e, fail)
+			if e.Target != "" && e.Target != TargetNone && !choiceTargets[e.Target] {
+				fail(effect+".target", "abilities can't use target %q", e.Target)
+			}
+		}
+	}
+
+	if len(c.Auras) > 0 && c.Type != TypeCreature {
+		fail("auras", "only creatures have auras")
+	}
+	for i, a := range c.Auras {
+		field := fmt.Sprintf("auras[%d]", i)
+		if !knownAuraScopes[a.Scope] {
+			fail(field+".scope", "unknown aura scope %q", a.Scope)
+		}
+		if a.BuffAttack == 0 && a.BuffHealth == 0 {
+			fail(field, "aura needs an attack or health buff")
+		}
+	}
+
+	return errs
+}
+
+func validateEffect(field string, e Effect, fail func(field, format string, args ...any)) {
+	if !knownEffectKinds[e.Kind] {
+		fail(field+".kind", "unknown effect kind %q", e.Kind)
+	}
+	if !knownTargetKinds[e.Target] {
+		fail(field+".target", "unknown target kind %q", e.Target)
+	}
+	if e.Amount < 0 {
+		fail(field+".amount", "must not be negative, got %d", e.Amount)
+	}
+
+	if e.Condition != "" {
+		if _, err := ParseCondition(e.Condition); err != nil {
+			fail(field+".condition", "%v", err)
+		}
+	}
+	if e.Value != "" {
+		if _, err := ParseValue(e.Value); err != nil {
+			fail(field+".value", "%v", err)
+		}
+		if e.Amount != 0 {
+			fail(field+".value", "amount and value can't both be set")
+		}
+		if !amountEffects[e.Kind] {
+			fail(field+".value", "%s has no amount to set", e.Kind)
+		}
+	}
+
+	if allowed := effectTargets[e.Kind]; allowed != nil && knownTargetKinds[e.Target] && !allowed[e.Target] {
+		fail(field+".target", "%s can't target %q", e.Kind, e.Target)
+	}
+	switch {
+	case e.Target == TargetUpToN && e.MaxTargets < 1:
+		fail(field+".max_targets", "%s needs max_targets of at least 1", TargetUpToN)
+	case e.Target != TargetUpToN && e.MaxTargets != 0:
+		fail(field+".max_targets", "only %s effects have max_targets", TargetUpToN)
+	}
+
+	switch {
+	case e.Kind == EffectTransform && e.Into == nil:
+		fail(field+".into", "transform needs a card to turn into")
+	case e.Kind == EffectTransform:
+		if e.Into.Type != TypeCreature {
+			fail(field+".into.type", "must be a creature, got %q", e.Into.Type)
+		}
+		for _, ve := range e.Into.validate() {
+			fail(field+".into."+ve.Field, "%s", ve.Msg)
+		}
+	case e.Into != nil:
+		fail(field+".into", "only %s effects have into", EffectTransform)
+	}
+
+	switch {
+	case e.Kind == EffectSummon && e.CardID == "":
+		fail(field+".card_id", "summon needs a token card")
+	case e.Kind != EffectSummon && e.CardID != "":
+		fail(field+".card_id", "only %s effects have card_id", EffectSummon)
+	}
+
+	if e.Random && e.Kind != EffectDiscard {
+		fail(field+".random", "only %s effects can be random", EffectDiscard)
+	}
+	switch {
+	case e.Filter != nil && e.Kind != EffectTutor:
+		fail(field+".filter", "only %s effects have a filter", EffectTutor)
+	case e.Filter != nil:
+		if e.Filter.Type != "" && !knownTypes[e.Filter.Type] {
+			fail(field+".filter.type", "unknown type %q", e.Filter.Type)
+		}
+		if e.Filter.Keyword != "" && !knownKeywords[e.Filter.Keyword] {
+			fail(field+".filter.keyword", "unknown keyword %q", e.Filter.Keyword)
+		}
+		if e.Filter.MaxCost != nil && *e.Filter.MaxCost < 0 {
+			fail(field+".filter.max_cost", "must not be negative, got %d", *e.Filter.MaxCost)
+		}
+	}
+
+	if e.Kind != EffectChooseOne {
+		if len(e.Choices) > 0 {
+			fail(field+".choices", "only %s effects have choices", EffectChooseOne)
+		}
+		return
+	}
+	if len(e.Choices) < 2 {
+		fail(field+".choices", "need at least two choices, got %d", len(e.Choices))
+	}
+	for i, c := range e.Choices {
+		choice := fmt.Sprintf("%s.choices[%d]", field, i)
+		validateEffect(choice, c, fail)
+		if !choiceTargets[c.Target] {
+			fail(choice+".target", "choices can't use target %q", c.Target)
+		}
+	}
+}
+
+// union returns a set holding every member of sets.
+func union(sets ...map[TargetKind]bool) map[TargetKind]bool {
+	out := make(map[TargetKind]bool)
+	for _, set := range sets {
+		for k := range set {
+			out[k] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrGameOver          = errors.New("game is over")
+	ErrUnknownActionType = errors.New("unknown action type")
+)
+
+// Action is a single request to change the game state. Every change a client
+// makes goes through Game.Submit as an Action.
+//
+// Validate reports whether the action is currently legal and must not modify
+// the game. Apply performs the change and may assume Validate returned nil.
+type Action interface {
+	Validate(g *Game) error
+	Apply(g *Game) error
+}
+
+// Submit validates and applies an action. It is the single entry point for
+// changing game state.
+func (g *Game) Submit(a Action) error {
+	if g.GameEnded {
+		return ErrGameOver
+	}
+
+	if g.inMulligan() && !isMulliganAction(a) {
+		return ErrMulliganPending
+	}
+
+	if g.PendingDecision() != nil && !isDecisionAction(a) {
+		return ErrDecisionPending
+	}
+
+	if err := a.Validate(g); err != nil {
+		return err
+	}
+
+	if err := a.Apply(g); err != nil {
+		return err
+	}
+	g.recomputeStats()
+
+	g.History = append(g.History, a)
+	return nil
+}
+
+// actionTypes names every concrete action for JSON encoding.
+var actionTypes = map[string]func() Action{
+	"play_card":         func() Action { return &PlayCardAction{} },
+	"start_turn":        func() Action { return &StartTurnAction{} },
+	"end_turn":          func() Action { return &EndTurnAction{} },
+	"pass_priority":     func() Action { return &PassPriorityAction{} },
+	"declare_attackers": func() Action { return &DeclareAttackersAction{} },
+	"declare_blockers":  func() Action { return &DeclareBlockersAction{} },
+	"resolve_combat":    func() Action { return &ResolveCombatAction{} },
+	"mulligan":          func() Action { return &MulliganAction{} },
+	"keep_hand":         func() Action { return &KeepHandAction{} },
+	"resolve_decision":  func() Action { return &ResolveDecisionAction{} },
+	"decision_timeout":  func() Action { return &DecisionTimeoutAction{} },
+}
+
+// actionEnvelope is the JSON form of an action: its type name plus fields.
+type actionEnvelope struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MarshalAction encodes an action as {"type": ..., "data": {...}}.
+func MarshalAction(a Action) ([]byte, error) {
+	name := actionTypeName(a)
+	if name == "" {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownActionType, a)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(actionEnvelope{Type: name, Data: data})
+}
+
+// UnmarshalAction decodes an action produced by MarshalAction.
+func UnmarshalAction(data []byte) (Action, error) {
+	var env actionEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	newAction, ok := actionTypes[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownActionType, env.Type)
+	}
+
+	ptr := newAction()
+	if len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, ptr); err != nil {
+			return nil, fmt.Errorf("decoding %s action: %w", env.Type, err)
+		}
+	}
+
+	// Actions are submitted by value
+	return reflect.ValueOf(ptr).Elem().Interface().(Action), nil
+}
+
+func actionTypeName(a Action) string {
+	t := reflect.TypeOf(a)
+	for name, newAction := range actionTypes {
+		if reflect.TypeOf(newAction()).Elem() == t {
+			return name
+		}
+	}
+	return ""
+}
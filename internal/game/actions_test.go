@@ -0,0 +1,184 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestSubmit_ValidatesBeforeApplying(t *testing.T) {
+	cases := []struct {
+		name    string
+		setup   func(t *testing.T, g *Game)
+		action  Action
+		wantErr error
+	}{
+		{
+			name:    "end turn by the active player",
+			action:  EndTurnAction{PlayerID: "p0"},
+			wantErr: nil,
+		},
+		{
+			name:    "end turn by the waiting player",
+			action:  EndTurnAction{PlayerID: "p1"},
+			wantErr: ErrNotYourTurn,
+		},
+		{
+			name:    "play by an unknown player",
+			action:  PlayCardAction{PlayerID: "nobody", HandIdx: 0},
+			wantErr: ErrPlayerNotFound,
+		},
+		{
+			name:    "end turn before starting it",
+			setup:   func(t *testing.T, g *Game) { g.TurnStarted = false },
+			action:  EndTurnAction{PlayerID: "p0"},
+			wantErr: ErrTurnNotStarted,
+		},
+		{
+			name:    "start turn",
+			setup:   func(t *testing.T, g *Game) { g.TurnStarted = false },
+			action:  StartTurnAction{PlayerID: "p0"},
+			wantErr: nil,
+		},
+		{
+			name:    "start turn by the waiting player",
+			setup:   func(t *testing.T, g *Game) { g.TurnStarted = false },
+			action:  StartTurnAction{PlayerID: "p1"},
+			wantErr: ErrNotYourTurn,
+		},
+		{
+			name:    "start turn twice",
+			action:  StartTurnAction{PlayerID: "p0"},
+			wantErr: ErrTurnStarted,
+		},
+		{
+			name: "start turn with a spell on the stack",
+			setup: func(t *testing.T, g *Game) {
+				g.Options.PriorityPassing = true
+				pushDummySpell(t, g)
+				g.TurnStarted = false
+			},
+			action:  StartTurnAction{PlayerID: "p0"},
+			wantErr: ErrStackNotEmpty,
+		},
+		{
+			name: "end turn with a spell on the stack",
+			setup: func(t *testing.T, g *Game) {
+				g.Options.PriorityPassing = true
+				pushDummySpell(t, g)
+			},
+			action:  EndTurnAction{PlayerID: "p0"},
+			wantErr: ErrStackNotEmpty,
+		},
+		{
+			name: "play card before starting the turn",
+			setup: func(t *testing.T, g *Game) {
+				g.TurnStarted = false
+				g.Players[0].Hand = append(g.Players[0].Hand, newCreature("h#1", "Bear", "p0", 2, 2))
+			},
+			action:  PlayCardAction{PlayerID: "p0", HandIdx: 0},
+			wantErr: ErrTurnNotStarted,
+		},
+		{
+			name:    "play card with bad hand index",
+			action:  PlayCardAction{PlayerID: "p0", HandIdx: 5},
+			wantErr: ErrInvalidHandIndex,
+		},
+		{
+			name: "play creature onto a full board",
+			setup: func(t *testing.T, g *Game) {
+				g.Options.MaxBoardSize = 1
+				g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+				g.Players[0].Hand = append(g.Players[0].Hand, newCreature("h#1", "Bear", "p0", 2, 2))
+			},
+			action:  PlayCardAction{PlayerID: "p0", HandIdx: 0},
+			wantErr: ErrBoardFull,
+		},
+		{
+			name: "attack before starting the turn",
+			setup: func(t *testing.T, g *Game) {
+				g.TurnStarted = false
+				g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+			},
+			action:  DeclareAttackersAction{PlayerID: "p0", Attackers: []InstanceID{"a#1"}},
+			wantErr: ErrTurnNotStarted,
+		},
+		{
+			name:    "pass priority with an empty stack",
+			action:  PassPriorityAction{PlayerID: "p0"},
+			wantErr: ErrStackEmpty,
+		},
+		{
+			name:    "resolve combat outside combat",
+			action:  ResolveCombatAction{PlayerID: "p0"},
+			wantErr: ErrWrongCombatPhase,
+		},
+		{
+			name:    "anything after the game ended",
+			setup:   func(t *testing.T, g *Game) { g.GameEnded = true },
+			action:  EndTurnAction{PlayerID: "p0"},
+			wantErr: ErrGameOver,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			g.Players[0].MaxEnergy, g.Players[0].CurrentEnergy = 10, 10
+			if tc.setup != nil {
+				tc.setup(t, g)
+			}
+			before := len(g.Log)
+
+			err := g.Submit(tc.action)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Greater(t, len(g.Log), before, "applied action should be logged")
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, before, len(g.Log), "rejected action must not change the game")
+			}
+		})
+	}
+}
+
+func TestCanPlayCard_MatchesValidate(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 1, 1
+	p0.Hand = append(p0.Hand,
+		CardInstance{InstanceID: "h#1", Def: &cards.CardDef{ID: "cheap", Type: cards.TypeCreature, Cost: 1}},
+		CardInstance{InstanceID: "h#2", Def: &cards.CardDef{ID: "pricey", Type: cards.TypeCreature, Cost: 5}},
+	)
+
+	for idx := range p0.Hand {
+		action := PlayCardAction{PlayerID: "p0", HandIdx: idx}
+		assert.Equal(t, action.Validate(g), g.CanPlayCard("p0", idx, nil))
+	}
+	assert.ErrorIs(t, g.CanPlayCard("p0", 1, nil), ErrNotEnoughEnergy)
+}
+
+func TestSubmit_FullTurnCycle(t *testing.T) {
+	g, err := NewGame("p0", "p1", smallDeck(10), smallDeck(10), Options{StartingHand: 3, Seed: 5})
+	require.NoError(t, err)
+
+	require.NoError(t, g.Submit(StartTurnAction{PlayerID: "p0"}))
+	require.NoError(t, g.Submit(PlayCardAction{PlayerID: "p0", HandIdx: 0}))
+	require.Len(t, g.Players[0].Board, 1)
+	require.NoError(t, g.Submit(EndTurnAction{PlayerID: "p0"}))
+
+	require.NoError(t, g.Submit(StartTurnAction{PlayerID: "p1"}))
+	assert.Equal(t, 1, g.Active)
+	require.NoError(t, g.Submit(EndTurnAction{PlayerID: "p1"}))
+
+	require.NoError(t, g.Submit(StartTurnAction{PlayerID: "p0"}))
+	assert.ErrorIs(t, g.Submit(StartTurnAction{PlayerID: "p0"}), ErrTurnStarted, "one start per turn")
+	require.NoError(t, g.Submit(DeclareAttackersAction{PlayerID: "p0", Attackers: []InstanceID{g.Players[0].Board[0].InstanceID}}))
+	require.NoError(t, g.Submit(DeclareBlockersAction{PlayerID: "p1"}))
+	require.NoError(t, g.Submit(ResolveCombatAction{PlayerID: "p0"}))
+
+	assert.Equal(t, 19, g.Players[1].Life)
+}
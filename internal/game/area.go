@@ -0,0 +1,85 @@
+package game
+
+import (
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// expandTargets lists the targets of an effect that hits several at once,
+// or that the engine picks at random. They are found when the effect
+// resolves, so creatures summoned in response are hit too. ok is false for
+// effects with a single target.
+func (g *Game) expandTargets(effect cards.Effect, target *TargetRef, caster *PlayerState) (targets []*TargetRef, ok bool) {
+	opponent := g.opponentOf(caster)
+
+	switch effect.Target {
+	case cards.TargetAllEnemyCreatures:
+		return characters(opponent, false), true
+	case cards.TargetAllAllyCreatures:
+		return characters(caster, false), true
+	case cards.TargetAllCreatures:
+		return append(characters(g.Players[0], false), characters(g.Players[1], false)...), true
+	case cards.TargetAllEnemyCharacters:
+		return characters(opponent, true), true
+	case cards.TargetAllAllyCharacters:
+		return characters(caster, true), true
+	case cards.TargetAllCharacters:
+		return append(characters(g.Players[0], true), characters(g.Players[1], true)...), true
+
+	case cards.TargetRandomEnemyCreature:
+		if len(opponent.Board) == 0 {
+			g.log("target", caster.PlayerID, "no enemy creature to pick")
+			return nil, true
+		}
+		picked := opponent.Board[g.Rand.Intn(len(opponent.Board))].InstanceID
+		return []*TargetRef{{InstanceID: &picked}}, true
+
+	case cards.TargetUpToN:
+		if target == nil {
+			return nil, true
+		}
+		for _, id := range target.InstanceIDs {
+			if _, found := g.findCardInstance(id); found {
+				targets = append(targets, &TargetRef{InstanceID: &id})
+			}
+		}
+		return targets, true
+	}
+	return nil, false
+}
+
+// characters returns a target for each creature on the player's board,
+// preceded by the player themselves when withPlayer is set.
+func characters(ps *PlayerState, withPlayer bool) []*TargetRef {
+	var out []*TargetRef
+	if withPlayer {
+		out = append(out, &TargetRef{PlayerID: ps.PlayerID})
+	}
+	for i := range ps.Board {
+		id := ps.Board[i].InstanceID
+		out = append(out, &TargetRef{InstanceID: &id})
+	}
+	return out
+}
+
+// applySimultaneously resolves one effect against every target as a single
+// event. Creatures hit don't die until all targets have been hit; the deaths
+// are then handled in one state-based effects pass, after which on_damaged
+// triggers fire in target order. Conditions and values are evaluated for
+// each target in turn.
+func (g *Game) applySimultaneously(resolve func(*EffectContext) error, ctx *EffectContext, e cards.Effect, targets []*TargetRef, what string) {
+	ctx.simultaneous = true
+	for _, target := range targets {
+		ctx.Target = target
+		if !g.prepareEffect(ctx, e, what) {
+			continue
+		}
+		if err := resolve(ctx); err != nil {
+			g.log("error", ctx.Caster.PlayerID, "%s: %v", what, err)
+		}
+	}
+
+	g.resolveStateBasedEffects()
+	for _, damaged := range ctx.damaged {
+		g.fireTrigger(damaged, cards.TriggerOnDamaged)
+	}
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestArea_DamageAllEnemyCreatures(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 1))
+	p1.Board = append(p1.Board,
+		newCreature("e#1", "Small", "p1", 1, 1),
+		newCreature("e#2", "Big", "p1", 1, 3),
+		newCreature("e#3", "Shielded", "p1", 1, 1),
+	)
+	p1.Board[2].DivineShield = true
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetAllEnemyCreatures})
+
+	assert.Equal(t, []string{"a#1"}, collectIDs(p0.Board))
+	assert.Equal(t, []string{"e#2", "e#3"}, collectIDs(p1.Board))
+	assert.Equal(t, 2, p1.Board[0].CurrentHealth)
+	assert.False(t, p1.Board[1].DivineShield)
+	assert.Equal(t, 20, p1.Life)
+}
+
+func TestArea_DeathsWaitForEveryHit(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+
+	// Were the blast resolved one creature at a time, the first death would
+	// save the second creature before it is hit
+	saviour := withAbility(newCreature("s#1", "Saviour", "p1", 1, 1), cards.TriggerOnDeath,
+		cards.Effect{Kind: cards.EffectBuffStatsPerm, BuffHealth: 5, Target: cards.TargetAllAllyCreatures})
+	p1.Board = append(p1.Board, saviour, newCreature("v#1", "Victim", "p1", 1, 1))
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetAllCreatures})
+
+	assert.Empty(t, p1.Board)
+	assert.Equal(t, []string{"s#1", "v#1"}, collectIDs(p1.Graveyard))
+}
+
+func TestArea_Characters(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 5))
+	p1.Board = append(p1.Board, newCreature("e#1", "Enemy", "p1", 1, 5))
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Amount: 2, Target: cards.TargetAllCharacters})
+	assert.Equal(t, 18, p0.Life)
+	assert.Equal(t, 18, p1.Life)
+	assert.Equal(t, 3, p0.Board[0].CurrentHealth)
+	assert.Equal(t, 3, p1.Board[0].CurrentHealth)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectHeal, Amount: 5, Target: cards.TargetAllAllyCharacters})
+	assert.Equal(t, 23, p0.Life)
+	assert.Equal(t, 5, p0.Board[0].CurrentHealth, "creatures heal up to their health only")
+	assert.Equal(t, 3, p1.Board[0].CurrentHealth)
+}
+
+func TestArea_DestroyAll(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 1))
+	p1.Board = append(p1.Board, withAbility(newCreature("e#1", "Enemy", "p1", 1, 1), cards.TriggerOnDeath,
+		cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyPlayer}))
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetAllCreatures})
+
+	assert.Empty(t, p0.Board)
+	assert.Empty(t, p1.Board)
+	assert.Equal(t, 17, p0.Life, "on_death fires")
+}
+
+func TestArea_RandomEnemyCreature(t *testing.T) {
+	hit := func() []string {
+		g := newCombatGame(t)
+		p1 := g.Players[1]
+		for _, id := range []string{"e#1", "e#2", "e#3", "e#4"} {
+			p1.Board = append(p1.Board, newCreature(id, "Enemy", "p1", 1, 1))
+		}
+		castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetRandomEnemyCreature})
+		assert.Len(t, p1.Board, 3)
+		return collectIDs(p1.Graveyard)
+	}
+	assert.Equal(t, hit(), hit(), "the same seed picks the same creature")
+
+	g := newCombatGame(t)
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetRandomEnemyCreature})
+	assert.Equal(t, 20, g.Players[1].Life, "no creature, no damage")
+}
+
+func TestArea_UpToNTargets(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 3))
+	p1.Board = append(p1.Board, newCreature("e#1", "Enemy", "p1", 1, 3), newCreature("e#2", "Enemy", "p1", 1, 3))
+
+	bolt := cards.Effect{Kind: cards.EffectDamage, Amount: 1, MaxTargets: 2, Target: cards.TargetUpToN}
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", bolt))
+
+	plays := 0
+	for _, a := range g.LegalActions("p0") {
+		if _, ok := a.(PlayCardAction); ok {
+			plays++
+		}
+	}
+	assert.Equal(t, 7, plays, "none, any one or any two of three creatures")
+
+	// A crowded board only lists the sets that fit under the cap
+	for i := range 9 {
+		p1.Board = append(p1.Board, newCreature(fmt.Sprintf("f#%d", i), "Filler", "p1", 1, 3))
+	}
+	plays = 0
+	for _, a := range g.LegalActions("p0") {
+		if _, ok := a.(PlayCardAction); ok {
+			plays++
+		}
+	}
+	assert.Equal(t, 13, plays, "none or any one of twelve creatures")
+	p1.Board = p1.Board[:2]
+
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceIDs: []InstanceID{"a#1", "e#1", "e#2"}}}), ErrInvalidTarget)
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceIDs: []InstanceID{"e#1", "e#1"}}}), ErrInvalidTarget)
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceIDs: []InstanceID{"nope#1"}}}), ErrInvalidTarget)
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceIDs: []InstanceID{"a#1", "e#2"}}}))
+	assert.Equal(t, 2, p0.Board[0].CurrentHealth)
+	assert.Equal(t, 3, p1.Board[0].CurrentHealth)
+	assert.Equal(t, 2, p1.Board[1].CurrentHealth)
+}
+
+func TestArea_GameEndsOnce(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p1.Life = 2
+
+	// The first effect ends the game; the second would leave both players dead
+	castSpell(t, g,
+		cards.Effect{Kind: cards.EffectDamage, Amount: 2, Target: cards.TargetAllEnemyCharacters},
+		cards.Effect{Kind: cards.EffectDamage, Amount: 30, Target: cards.TargetAllAllyCharacters},
+	)
+
+	ends := 0
+	for _, e := range g.Log {
+		if e.Type == "game_end" {
+			ends++
+		}
+	}
+	assert.Equal(t, 1, ends)
+	assert.True(t, g.GameEnded)
+	assert.Contains(t, g.Winner, p0.Name)
+	assert.Equal(t, 20, p0.Life, "nothing resolves after the game ends")
+}
@@ -0,0 +1,329 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+var (
+	ErrWrongCombatPhase   = errors.New("wrong combat phase")
+	ErrNotDefendingPlayer = errors.New("not the defending player")
+	ErrInvalidAttacker    = errors.New("invalid attacker")
+	ErrInvalidBlocker     = errors.New("invalid blocker")
+	ErrSummoningSick      = errors.New("creature is summoning sick")
+	ErrCreatureExhausted  = errors.New("creature is exhausted")
+	ErrAlreadyAttacked    = errors.New("creature already attacked this turn")
+	ErrCreatureFrozen     = errors.New("creature is frozen")
+	ErrMustAttackTaunt    = errors.New("must attack a taunt creature")
+)
+
+// DeclareAttackersAction is the first combat step. The active player picks
+// which of their ready creatures attack. A creature attacks at most once a
+// turn; attackers without vigilance are also exhausted, and the game waits
+// for the defending player to declare blockers.
+//
+// Targets maps an attacker to the enemy creature it attacks; attackers
+// missing from it attack the defending player. While the defender has a ready
+// taunt creature, every attacker must attack one.
+type DeclareAttackersAction struct {
+	PlayerID  string                    `json:"player_id"`
+	Attackers []InstanceID              `json:"attackers"`
+	Targets   map[InstanceID]InstanceID `json:"targets,omitempty"`
+}
+
+func (a DeclareAttackersAction) Validate(g *Game) error {
+	playerID, attackerIDs := a.PlayerID, a.Attackers
+
+	if g.CurrentPlayer().PlayerID != playerID {
+		return ErrNotYourTurn
+	}
+
+	if !g.TurnStarted {
+		return ErrTurnNotStarted
+	}
+
+	if g.CombatPhase != PhaseNone && g.CombatPhase != PhaseAttackers {
+		return ErrWrongCombatPhase
+	}
+
+	if len(g.Stack) > 0 {
+		return ErrStackNotEmpty
+	}
+
+	if len(attackerIDs) == 0 {
+		return fmt.Errorf("%w: no attackers declared", ErrInvalidAttacker)
+	}
+
+	activePlayer := g.CurrentPlayer()
+
+	seen := make(map[InstanceID]bool, len(attackerIDs))
+	for _, id := range attackerIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: %s declared twice", ErrInvalidAttacker, id)
+		}
+		seen[id] = true
+
+		ci, ok := g.findCardInstance(id)
+		if !ok || !g.controls(activePlayer, ci) || ci.Def.Type != cards.TypeCreature {
+			return fmt.Errorf("%w: %s", ErrInvalidAttacker, id)
+		}
+		if ci.SummoningSick {
+			return fmt.Errorf("%w: %s", ErrSummoningSick, id)
+		}
+		if ci.Exhausted {
+			return fmt.Errorf("%w: %s", ErrCreatureExhausted, id)
+		}
+		if ci.AttackedThisTurn {
+			return fmt.Errorf("%w: %s", ErrAlreadyAttacked, id)
+		}
+		if g.frozen(ci) {
+			return fmt.Errorf("%w: %s", ErrCreatureFrozen, id)
+		}
+	}
+
+	defender := g.Opponent()
+	for attackerID, targetID := range a.Targets {
+		if !seen[attackerID] {
+			return fmt.Errorf("%w: %s is not attacking", ErrInvalidAttacker, attackerID)
+		}
+		ci, ok := g.findCardInstance(targetID)
+		if !ok || !g.controls(defender, ci) || ci.Def.Type != cards.TypeCreature {
+			return fmt.Errorf("%w: %s", ErrInvalidTarget, targetID)
+		}
+	}
+
+	// Ready taunt creatures draw every attack
+	if g.hasReadyTaunt(defender) {
+		for _, id := range attackerIDs {
+			target, ok := g.findCardInstance(a.Targets[id])
+			if !ok || !target.Def.HasKeyword(cards.KeywordTaunt) || target.Exhausted {
+				return fmt.Errorf("%w: %s", ErrMustAttackTaunt, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasReadyTaunt reports whether any of the player's creatures has taunt and
+// is not exhausted.
+func (g *Game) hasReadyTaunt(ps *PlayerState) bool {
+	for i := range ps.Board {
+		if ps.Board[i].Def.HasKeyword(cards.KeywordTaunt) && !ps.Board[i].Exhausted {
+			return true
+		}
+	}
+	return false
+}
+
+func (a DeclareAttackersAction) Apply(g *Game) error {
+	g.CombatPhase = PhaseAttackers
+	g.AttackingIDs = append(make([]InstanceID, 0, len(a.Attackers)), a.Attackers...)
+	g.AttackTargets = make(map[InstanceID]InstanceID, len(a.Targets))
+	g.BlockingPairs = make(map[InstanceID]InstanceID)
+
+	for _, id := range g.AttackingIDs {
+		ci, _ := g.findCardInstance(id)
+		ci.AttackedThisTurn = true
+		if !ci.Def.HasKeyword(cards.KeywordVigilance) {
+			ci.Exhausted = true
+		}
+		targetID, ok := a.Targets[id]
+		if !ok {
+			g.log("attack", a.PlayerID, "%s (%s) attacks", ci.Def.Name, ci.InstanceID)
+			continue
+		}
+		g.AttackTargets[id] = targetID
+		target, _ := g.findCardInstance(targetID)
+		g.log("attack", a.PlayerID, "%s (%s) attacks %s (%s)", ci.Def.Name, ci.InstanceID, target.Def.Name, targetID)
+	}
+
+	g.CombatPhase = PhaseBlockers
+	return nil
+}
+
+// DeclareBlockersAction is the second combat step. The defending player
+// assigns at most one ready creature to each attacker (attacker -> blocker);
+// attackers missing from the map are unblocked. An empty map is a valid
+// "no blocks".
+type DeclareBlockersAction struct {
+	PlayerID string                    `json:"player_id"`
+	Blocks   map[InstanceID]InstanceID `json:"blocks,omitempty"`
+}
+
+func (a DeclareBlockersAction) Validate(g *Game) error {
+	playerID, blocks := a.PlayerID, a.Blocks
+
+	if g.Opponent().PlayerID != playerID {
+		return ErrNotDefendingPlayer
+	}
+
+	if g.CombatPhase != PhaseBlockers {
+		return ErrWrongCombatPhase
+	}
+
+	if len(g.Stack) > 0 {
+		return ErrStackNotEmpty
+	}
+
+	defender := g.Opponent()
+
+	attacking := make(map[InstanceID]bool, len(g.AttackingIDs))
+	for _, id := range g.AttackingIDs {
+		attacking[id] = true
+	}
+
+	used := make(map[InstanceID]bool, len(blocks))
+	for attackerID, blockerID := range blocks {
+		if !attacking[attackerID] {
+			return fmt.Errorf("%w: %s is not attacking", ErrInvalidAttacker, attackerID)
+		}
+		if used[blockerID] {
+			return fmt.Errorf("%w: %s is already blocking", ErrInvalidBlocker, blockerID)
+		}
+		used[blockerID] = true
+
+		ci, ok := g.findCardInstance(blockerID)
+		if !ok || !g.controls(defender, ci) || ci.Def.Type != cards.TypeCreature {
+			return fmt.Errorf("%w: %s", ErrInvalidBlocker, blockerID)
+		}
+		if ci.Exhausted {
+			return fmt.Errorf("%w: %s", ErrCreatureExhausted, blockerID)
+		}
+	}
+
+	return nil
+}
+
+func (a DeclareBlockersAction) Apply(g *Game) error {
+	g.BlockingPairs = make(map[InstanceID]InstanceID, len(a.Blocks))
+	for _, attackerID := range g.AttackingIDs {
+		blockerID, ok := a.Blocks[attackerID]
+		if !ok {
+			continue
+		}
+		g.BlockingPairs[attackerID] = blockerID
+		g.log("block", a.PlayerID, "%s blocks %s", blockerID, attackerID)
+	}
+
+	g.CombatPhase = PhaseDamage
+	return nil
+}
+
+// ResolveCombatAction is the final combat step. All combat damage is dealt
+// simultaneously: blocked attackers and their blockers damage each other,
+// unblocked attackers damage the creature they attacked or else the
+// defending player. Deaths are handled by the
+// state-based check afterwards.
+type ResolveCombatAction struct {
+	PlayerID string `json:"player_id"`
+}
+
+func (a ResolveCombatAction) Validate(g *Game) error {
+	if g.CurrentPlayer().PlayerID != a.PlayerID {
+		return ErrNotYourTurn
+	}
+
+	if g.CombatPhase != PhaseDamage {
+		return ErrWrongCombatPhase
+	}
+
+	if len(g.Stack) > 0 {
+		return ErrStackNotEmpty
+	}
+
+	return nil
+}
+
+func (a ResolveCombatAction) Apply(g *Game) error {
+	playerID := a.PlayerID
+	defender := g.Opponent()
+
+	type combatHit struct {
+		source *CardInstance
+		target *CardInstance
+		amount int
+	}
+	var hits []combatHit
+	playerDamage := 0
+
+	for _, attackerID := range g.AttackingIDs {
+		attacker, ok := g.findCardInstance(attackerID)
+		if !ok {
+			// Attacker left the board before damage
+			continue
+		}
+
+		blockerID, blocked := g.BlockingPairs[attackerID]
+		if !blocked {
+			blockerID, blocked = g.AttackTargets[attackerID]
+		}
+		if !blocked {
+			playerDamage += attacker.CurrentAttack
+			g.log("combat_damage", playerID, "%s deals %d damage to %s", attacker.Def.Name, attacker.CurrentAttack, defender.PlayerID)
+			g.applyLifesteal(attacker, attacker.CurrentAttack)
+			continue
+		}
+
+		blocker, ok := g.findCardInstance(blockerID)
+		if !ok {
+			// A blocked attacker stays blocked even if its blocker is gone,
+			// and one that attacked a creature doesn't turn on the player
+			continue
+		}
+		hits = append(hits,
+			combatHit{source: attacker, target: blocker, amount: attacker.CurrentAttack},
+			combatHit{source: blocker, target: attacker, amount: blocker.CurrentAttack},
+		)
+	}
+
+	// Every amount was computed above, so applying now keeps damage simultaneous
+	var damaged []CardInstance
+	for _, hit := range hits {
+		dealt := g.damageCreature(hit.source, hit.target, hit.amount)
+		g.log("combat_damage", playerID, "%s deals %d damage to %s", hit.source.Def.Name, dealt, hit.target.Def.Name)
+		g.applyLifesteal(hit.source, dealt)
+		if dealt > 0 {
+			damaged = append(damaged, *hit.target)
+		}
+	}
+	defender.Life -= playerDamage
+
+	// Triggers may move cards, so they only fire once all damage is dealt
+	for _, ci := range damaged {
+		g.fireTrigger(ci, cards.TriggerOnDamaged)
+	}
+
+	g.clearCombat()
+	g.resolveStateBasedEffects()
+
+	return nil
+}
+
+// DeclareAttackers declares attacks on the defending player. Attacks on
+// creatures are declared with DeclareAttackersAction.Targets.
+func (g *Game) DeclareAttackers(playerID string, attackerIDs []InstanceID) error {
+	return g.Submit(DeclareAttackersAction{PlayerID: playerID, Attackers: attackerIDs})
+}
+
+func (g *Game) DeclareBlockers(playerID string, blocks map[InstanceID]InstanceID) error {
+	return g.Submit(DeclareBlockersAction{PlayerID: playerID, Blocks: blocks})
+}
+
+func (g *Game) ResolveCombat(playerID string) error {
+	return g.Submit(ResolveCombatAction{PlayerID: playerID})
+}
+
+func (g *Game) clearCombat() {
+	g.CombatPhase = PhaseNone
+	g.AttackingIDs = make([]InstanceID, 0)
+	g.AttackTargets = make(map[InstanceID]InstanceID)
+	g.BlockingPairs = make(map[InstanceID]InstanceID)
+}
+
+// frozen reports whether a freeze still keeps the creature from attacking.
+func (g *Game) frozen(ci *CardInstance) bool {
+	return ci.FrozenUntil > 0 && g.Turn <= ci.FrozenUntil
+}
@@ -0,0 +1,262 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeclareAttackers_Validation(t *testing.T) {
+	cases := []struct {
+		name     string
+		playerID string
+		setup    func(g *Game)
+		attacker InstanceID
+		wantErr  error
+	}{
+		{
+			name:     "legal attacker",
+			playerID: "p0",
+			attacker: "a#1",
+			wantErr:  nil,
+		},
+		{
+			name:     "wrong player turn",
+			playerID: "p1",
+			attacker: "a#1",
+			wantErr:  ErrNotYourTurn,
+		},
+		{
+			name:     "summoning sick attacker",
+			playerID: "p0",
+			setup:    func(g *Game) { g.Players[0].Board[0].SummoningSick = true },
+			attacker: "a#1",
+			wantErr:  ErrSummoningSick,
+		},
+		{
+			name:     "exhausted attacker",
+			playerID: "p0",
+			setup:    func(g *Game) { g.Players[0].Board[0].Exhausted = true },
+			attacker: "a#1",
+			wantErr:  ErrCreatureExhausted,
+		},
+		{
+			name:     "enemy creature cannot attack for you",
+			playerID: "p0",
+			attacker: "b#1",
+			wantErr:  ErrInvalidAttacker,
+		},
+		{
+			name:     "unknown creature",
+			playerID: "p0",
+			attacker: "bogus#99",
+			wantErr:  ErrInvalidAttacker,
+		},
+		{
+			name:     "combat already in progress",
+			playerID: "p0",
+			setup:    func(g *Game) { g.CombatPhase = PhaseBlockers },
+			attacker: "a#1",
+			wantErr:  ErrWrongCombatPhase,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+			g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wolf", "p1", 2, 2))
+			if tc.setup != nil {
+				tc.setup(g)
+			}
+			wasExhausted := g.Players[0].Board[0].Exhausted
+
+			err := g.DeclareAttackers(tc.playerID, []InstanceID{tc.attacker})
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				assert.True(t, g.Players[0].Board[0].Exhausted, "attacker should be exhausted")
+				assert.Equal(t, PhaseBlockers, g.CombatPhase)
+				assert.Equal(t, []InstanceID{tc.attacker}, g.AttackingIDs)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, wasExhausted, g.Players[0].Board[0].Exhausted, "failed declaration must not exhaust")
+			}
+		})
+	}
+}
+
+func TestDeclareBlockers_Validation(t *testing.T) {
+	cases := []struct {
+		name     string
+		playerID string
+		setup    func(g *Game)
+		blocks   map[InstanceID]InstanceID
+		wantErr  error
+	}{
+		{
+			name:     "legal block",
+			playerID: "p1",
+			blocks:   map[InstanceID]InstanceID{"a#1": "b#1"},
+			wantErr:  nil,
+		},
+		{
+			name:     "no blocks",
+			playerID: "p1",
+			blocks:   nil,
+			wantErr:  nil,
+		},
+		{
+			name:     "attacker cannot block",
+			playerID: "p0",
+			blocks:   map[InstanceID]InstanceID{"a#1": "b#1"},
+			wantErr:  ErrNotDefendingPlayer,
+		},
+		{
+			name:     "blocking a creature that is not attacking",
+			playerID: "p1",
+			blocks:   map[InstanceID]InstanceID{"a#2": "b#1"},
+			wantErr:  ErrInvalidAttacker,
+		},
+		{
+			name:     "blocking with own attacker",
+			playerID: "p1",
+			blocks:   map[InstanceID]InstanceID{"a#1": "a#2"},
+			wantErr:  ErrInvalidBlocker,
+		},
+		{
+			name:     "exhausted blocker",
+			playerID: "p1",
+			setup:    func(g *Game) { g.Players[1].Board[0].Exhausted = true },
+			blocks:   map[InstanceID]InstanceID{"a#1": "b#1"},
+			wantErr:  ErrCreatureExhausted,
+		},
+		{
+			name:     "before attackers are declared",
+			playerID: "p1",
+			setup:    func(g *Game) { g.CombatPhase = PhaseNone },
+			blocks:   map[InstanceID]InstanceID{"a#1": "b#1"},
+			wantErr:  ErrWrongCombatPhase,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			g.Players[0].Board = append(g.Players[0].Board,
+				newCreature("a#1", "Bear", "p0", 2, 2),
+				newCreature("a#2", "Bear", "p0", 2, 2),
+			)
+			g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wolf", "p1", 2, 2))
+			require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+			if tc.setup != nil {
+				tc.setup(g)
+			}
+
+			err := g.DeclareBlockers(tc.playerID, tc.blocks)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, PhaseDamage, g.CombatPhase)
+				assert.Len(t, g.BlockingPairs, len(tc.blocks))
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveCombat_UnblockedDamagesPlayer(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board,
+		newCreature("a#1", "Bear", "p0", 2, 2),
+		newCreature("a#2", "Ogre", "p0", 3, 3),
+	)
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1", "a#2"}))
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.Equal(t, 15, g.Players[1].Life, "defender should take 2+3 damage")
+	assert.Equal(t, PhaseNone, g.CombatPhase, "combat state should reset")
+	assert.Empty(t, g.AttackingIDs)
+	assert.Empty(t, g.BlockingPairs)
+	assert.Len(t, g.Players[0].Board, 2)
+}
+
+func TestResolveCombat_SimultaneousBlockDamage(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Ogre", "p0", 3, 3))
+	g.Players[1].Board = append(g.Players[1].Board,
+		newCreature("b#1", "Wolf", "p1", 3, 2),
+		newCreature("b#2", "Wall", "p1", 0, 5),
+	)
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#1"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	// Both creatures deal lethal damage at the same time
+	assert.Empty(t, g.Players[0].Board, "attacker should die")
+	assert.Len(t, g.Players[0].Graveyard, 1)
+	require.Len(t, g.Players[1].Board, 1, "only the blocker should die")
+	assert.Equal(t, InstanceID("b#2"), g.Players[1].Board[0].InstanceID)
+	assert.Len(t, g.Players[1].Graveyard, 1)
+	assert.Equal(t, 20, g.Players[1].Life, "blocked damage should not reach the player")
+}
+
+func TestResolveCombat_DamagePersistsUntilCleanup(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Ogre", "p0", 1, 3))
+	g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wall", "p1", 2, 5))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#1"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.Equal(t, 2, g.Players[0].Board[0].CurrentDamage)
+	assert.Equal(t, 1, g.Players[0].Board[0].CurrentHealth)
+	assert.Equal(t, 4, g.Players[1].Board[0].CurrentHealth)
+
+	g.EndTurn()
+
+	assert.Equal(t, 3, g.Players[0].Board[0].CurrentHealth, "damage should clear at end of turn")
+	assert.True(t, g.Players[0].Board[0].Exhausted, "attacker stays exhausted until its controller's next turn")
+}
+
+func TestResolveCombat_LethalEndsGame(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[1].Life = 2
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.True(t, g.GameEnded)
+	assert.Contains(t, g.Winner, g.Players[0].Name)
+}
+
+func TestResolveCombat_WrongPhase(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+
+	assert.ErrorIs(t, g.ResolveCombat("p0"), ErrWrongCombatPhase)
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	assert.ErrorIs(t, g.ResolveCombat("p0"), ErrWrongCombatPhase, "blockers must be declared first")
+
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	assert.ErrorIs(t, g.ResolveCombat("p1"), ErrNotYourTurn)
+}
+
+func TestPlayCard_CreatureEntersSummoningSick(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, newCreature("h#1", "Bear", "p0", 2, 2))
+
+	require.NoError(t, g.PlayCard("p0", 0, nil))
+	require.Len(t, p0.Board, 1)
+	assert.True(t, p0.Board[0].SummoningSick)
+	assert.ErrorIs(t, g.DeclareAttackers("p0", []InstanceID{"h#1"}), ErrSummoningSick)
+}
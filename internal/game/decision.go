@@ -0,0 +1,562 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+type DecisionKind string
+
+const (
+	DecisionChooseOne   DecisionKind = "choose_one"   // pick one effect of a choose_one card
+	DecisionDiscover    DecisionKind = "discover"     // pick one of the top deck cards to put in hand
+	DecisionDiscard     DecisionKind = "discard"      // pick hand cards to discard
+	DecisionScry        DecisionKind = "scry"         // pick the top deck cards to keep on top, in order
+	DecisionChooseCards DecisionKind = "choose_cards" // pick matching deck cards to put in hand
+)
+
+var (
+	ErrDecisionPending   = errors.New("waiting for a player decision")
+	ErrNoDecisionPending = errors.New("no such decision pending")
+	ErrNotYourDecision   = errors.New("decision belongs to another player")
+	ErrChoiceCount       = errors.New("wrong number of choices")
+	ErrInvalidChoice     = errors.New("invalid choice")
+)
+
+// DecisionOption is one answer a player can pick. Options standing for a
+// card carry its instance ID; choose_one options carry the effect to apply.
+type DecisionOption struct {
+	Label      string        `json:"label"`
+	InstanceID InstanceID    `json:"instance_id,omitempty"`
+	Effect     *cards.Effect `json:"effect,omitempty"`
+}
+
+// PendingDecision is a question the game is waiting on. Every other action
+// is rejected until PlayerID answers with exactly Count distinct option
+// indexes, or the decision times out and Default is used instead. When UpTo
+// is set any number up to Count is accepted and their order matters.
+//
+// A decision is plain data, so it survives snapshots; what happens on
+// resolution is decided by Kind.
+type PendingDecision struct {
+	ID       int              `json:"id"`
+	PlayerID string           `json:"player_id"`
+	Kind     DecisionKind     `json:"kind"`
+	Prompt   string           `json:"prompt"`
+	Options  []DecisionOption `json:"options"`
+	Count    int              `json:"count"`
+	UpTo     bool             `json:"up_to,omitempty"`
+	Default  []int            `json:"default"`
+	Source   InstanceID       `json:"source,omitempty"` // card that asked, if any
+
+	// SourceCard is the asking card as it was then, for its keywords once it
+	// has left the board or the stack.
+	SourceCard *CardInstance `json:"-"`
+
+	// Then holds the effects that were still to resolve when the decision
+	// was asked; they resolve after the answer.
+	Then []DeferredEffect `json:"then,omitempty"`
+}
+
+// DeferredEffect is an effect with its target already picked, waiting for a
+// decision asked by an earlier effect. SourceCard keeps a copy of the card it
+// comes from: a spell is in the graveyard by the time the effect resolves,
+// and its keywords still apply.
+type DeferredEffect struct {
+	Effect     cards.Effect  `json:"effect"`
+	Target     *TargetRef    `json:"target,omitempty"`
+	Caster     string        `json:"caster"`
+	Source     InstanceID    `json:"source,omitempty"`
+	SourceCard *CardInstance `json:"-"`
+}
+
+// PendingDecision returns the decision the game is waiting on, or nil.
+// Decisions opened by the same action are asked one at a time, in order.
+func (g *Game) PendingDecision() *PendingDecision {
+	if len(g.Decisions) == 0 {
+		return nil
+	}
+	return g.Decisions[0]
+}
+
+// askDecision queues d and gives it the next decision ID. Options are only
+// logged privately since they may name hidden cards.
+func (g *Game) askDecision(d *PendingDecision) {
+	g.decisionSeq++
+	d.ID = g.decisionSeq
+	g.Decisions = append(g.Decisions, d)
+
+	labels := make([]string, len(d.Options))
+	for i, o := range d.Options {
+		labels[i] = o.Label
+	}
+	g.logPrivate("decision", d.PlayerID, strings.Join(labels, ", "), "%s must decide: %s", d.PlayerID, d.Prompt)
+}
+
+func isDecisionAction(a Action) bool {
+	switch a.(type) {
+	case ResolveDecisionAction, DecisionTimeoutAction:
+		return true
+	}
+	return false
+}
+
+var knownDecisionKinds = map[DecisionKind]bool{
+	DecisionChooseOne:   true,
+	DecisionDiscover:    true,
+	DecisionDiscard:     true,
+	DecisionScry:        true,
+	DecisionChooseCards: true,
+}
+
+// checkDecision returns the pending decision if it is the one with the
+// given ID and the game can resolve it.
+func (g *Game) checkDecision(id int) (*PendingDecision, error) {
+	d := g.PendingDecision()
+	if d == nil || d.ID != id {
+		return nil, fmt.Errorf("%w: %d", ErrNoDecisionPending, id)
+	}
+	if !knownDecisionKinds[d.Kind] {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrNoDecisionPending, d.Kind)
+	}
+	if g.playerByID(d.PlayerID) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, d.PlayerID)
+	}
+	return d, nil
+}
+
+// checkChoices reports whether choices is a valid answer to d.
+func (d *PendingDecision) checkChoices(choices []int) error {
+	if len(choices) > d.Count || (!d.UpTo && len(choices) != d.Count) {
+		return fmt.Errorf("%w: got %d, want %d", ErrChoiceCount, len(choices), d.Count)
+	}
+
+	seen := make(map[int]bool, len(choices))
+	for _, c := range choices {
+		if c < 0 || c >= len(d.Options) || seen[c] {
+			return fmt.Errorf("%w: %d", ErrInvalidChoice, c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// ResolveDecisionAction answers the pending decision. Choices are indexes
+// into its Options.
+type ResolveDecisionAction struct {
+	PlayerID   string `json:"player_id"`
+	DecisionID int    `json:"decision_id"`
+	Choices    []int  `json:"choices"`
+}
+
+func (a ResolveDecisionAction) Validate(g *Game) error {
+	d, err := g.checkDecision(a.DecisionID)
+	if err != nil {
+		return err
+	}
+
+	if d.PlayerID != a.PlayerID {
+		return ErrNotYourDecision
+	}
+
+	return d.checkChoices(a.Choices)
+}
+
+func (a ResolveDecisionAction) Apply(g *Game) error {
+	g.log("decision", a.PlayerID, "%s decided", a.PlayerID)
+	g.resolveDecision(a.Choices)
+	return nil
+}
+
+// DecisionTimeoutAction applies the pending decision's default answer. The
+// engine has no clock: whoever runs the game submits it once
+// Options.DecisionTimeoutMs has passed.
+type DecisionTimeoutAction struct {
+	DecisionID int `json:"decision_id"`
+}
+
+func (a DecisionTimeoutAction) Validate(g *Game) error {
+	d, err := g.checkDecision(a.DecisionID)
+	if err != nil {
+		return err
+	}
+	return d.checkChoices(d.Default)
+}
+
+func (a DecisionTimeoutAction) Apply(g *Game) error {
+	d := g.PendingDecision()
+	g.log("decision", d.PlayerID, "%s timed out, default applied", d.PlayerID)
+	g.resolveDecision(d.Default)
+	return nil
+}
+
+// Resolve answers the pending decision on behalf of playerID.
+func (g *Game) Resolve(playerID string, choices []int) error {
+	a := ResolveDecisionAction{PlayerID: playerID, Choices: choices}
+	if d := g.PendingDecision(); d != nil {
+		a.DecisionID = d.ID
+	}
+	return g.Submit(a)
+}
+
+// TimeoutDecision applies the default answer to the pending decision.
+func (g *Game) TimeoutDecision() error {
+	var a DecisionTimeoutAction
+	if d := g.PendingDecision(); d != nil {
+		a.DecisionID = d.ID
+	}
+	return g.Submit(a)
+}
+
+// resolveDecision pops the pending decision and carries out the chosen
+// options, then the effects that waited for it. A turn that was waiting on
+// decisions carries on ending once the last one is answered. Validate has
+// checked the decision and choices, so nothing here can fail.
+func (g *Game) resolveDecision(choices []int) {
+	d := g.Decisions[0]
+	g.Decisions = g.Decisions[1:]
+	if len(g.Decisions) == 0 {
+		g.Decisions = nil
+	}
+	asked := len(g.Decisions)
+
+	ps := g.playerByID(d.PlayerID)
+	switch d.Kind {
+	case DecisionChooseOne:
+		g.resolveChoice(ps, d, d.Options[choices[0]])
+	case DecisionDiscover:
+		g.resolveDiscover(ps, d, choices[0])
+	case DecisionDiscard:
+		g.resolveDiscard(ps, d, choices)
+	case DecisionScry:
+		g.resolveScry(ps, d, choices)
+	case DecisionChooseCards:
+		g.resolveTutor(ps, d, choices)
+	}
+
+	// The answer may itself have asked something; the rest waits for that
+	if len(g.Decisions) > asked {
+		last := g.Decisions[len(g.Decisions)-1]
+		last.Then = append(last.Then, d.Then...)
+	} else {
+		g.runEffects(d.Then, nil, "deferred")
+	}
+
+	if g.EndingTurn && len(g.Decisions) == 0 {
+		g.continueEndTurn()
+	}
+	g.resolveStateBasedEffects()
+}
+
+// runEffects resolves effects in order through effectResolver. When one of
+// them asks a player something, the rest are handed to that decision and
+// resolve once it is answered. source is the card the effects come from;
+// when nil it is looked up on the boards, falling back to the copy each
+// effect carries. Effects stop resolving once the game has ended.
+func (g *Game) runEffects(effects []DeferredEffect, source *CardInstance, what string) {
+	asked := len(g.Decisions)
+	for i, de := range effects {
+		if g.GameEnded {
+			return
+		}
+
+		caster := g.playerByID(de.Caster)
+		if caster == nil {
+			g.log("error", "", "%s effect %d: caster %q not found", what, i, de.Caster)
+			continue
+		}
+
+		resolve, ok := effectResolver[de.Effect.Kind]
+		if !ok {
+			g.log("error", caster.PlayerID, "%s effect %d: unknown effect kind %q", what, i, de.Effect.Kind)
+			continue
+		}
+
+		src := source
+		if src == nil && de.Source != "" {
+			src, _ = g.findCardInstance(de.Source)
+		}
+		if src == nil {
+			src = de.SourceCard
+		}
+
+		// Earlier effects may have moved creatures in or out of aura range
+		g.recomputeStats()
+
+		e := de.Effect
+		ctx := EffectContext{Game: g, Caster: caster, Source: src, Target: de.Target, Amount: e.Amount, BuffAttack: e.BuffAttack, BuffHealth: e.BuffHealth, Choices: e.Choices, Into: e.Into, CardID: e.CardID, Random: e.Random, Filter: e.Filter}
+		label := fmt.Sprintf("%s effect %d", what, i)
+		if targets, ok := g.expandTargets(e, de.Target, caster); ok {
+			g.applySimultaneously(resolve, &ctx, e, targets, label)
+		} else if g.prepareEffect(&ctx, e, label) {
+			if err := resolve(&ctx); err != nil {
+				g.log("error", caster.PlayerID, "%s: %v", label, err)
+			}
+		}
+
+		if len(g.Decisions) > asked {
+			last := g.Decisions[len(g.Decisions)-1]
+			last.Then = append(last.Then, effects[i+1:]...)
+			return
+		}
+	}
+}
+
+// decisionActions lists every answer to the pending decision open to the
+// player. Big decisions such as scrying ten cards would list millions, so
+// past maxUpToNSets answers only the default is listed, plus keeping nothing
+// when any number may be picked.
+func (g *Game) decisionActions(playerID string) []Action {
+	d := g.PendingDecision()
+	if d.PlayerID != playerID {
+		return nil
+	}
+
+	var answers [][]int
+	switch {
+	case !d.UpTo && binomial(len(d.Options), d.Count) <= maxUpToNSets:
+		answers = handSubsets(len(d.Options), d.Count)
+	case d.UpTo && orderedSelectionCount(len(d.Options), d.Count) <= maxUpToNSets:
+		answers = orderedSelections(len(d.Options), d.Count)
+	case d.UpTo:
+		answers = [][]int{d.Default, nil}
+	default:
+		answers = [][]int{d.Default}
+	}
+
+	var actions []Action
+	for _, choices := range answers {
+		actions = append(actions, ResolveDecisionAction{PlayerID: playerID, DecisionID: d.ID, Choices: choices})
+	}
+	return actions
+}
+
+// orderedSelectionCount returns how many answers orderedSelections(n, k)
+// lists. Counting stops once it passes maxUpToNSets.
+func orderedSelectionCount(n, k int) int {
+	total, picks := 1, 1
+	for i := range min(n, k) {
+		picks *= n - i
+		total += picks
+		if total > maxUpToNSets {
+			break
+		}
+	}
+	return total
+}
+
+// orderedSelections lists every ordered pick of at most k distinct indexes
+// below n, starting with the empty one.
+func orderedSelections(n, k int) [][]int {
+	out := [][]int{nil}
+	var walk func(picked []int)
+	walk = func(picked []int) {
+		if len(picked) == k {
+			return
+		}
+		for i := range n {
+			if slices.Contains(picked, i) {
+				continue
+			}
+			next := append(slices.Clone(picked), i)
+			out = append(out, next)
+			walk(next)
+		}
+	}
+	walk(nil)
+	return out
+}
+
+// applyChooseOne asks the target player to pick one of the effect's choices.
+func applyChooseOne(ctx *EffectContext) error {
+	player, err := ctx.Game.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyChooseOne: %w", err)
+	}
+
+	d := &PendingDecision{
+		PlayerID: player.PlayerID,
+		Kind:     DecisionChooseOne,
+		Prompt:   "choose one",
+		Count:    1,
+		Default:  []int{0},
+	}
+	if ctx.Source != nil {
+		d.Prompt = fmt.Sprintf("%s: choose one", ctx.Source.Def.Name)
+		d.Source = ctx.Source.InstanceID
+		source := *ctx.Source
+		d.SourceCard = &source
+	}
+	for _, choice := range ctx.Choices {
+		effect := choice
+		d.Options = append(d.Options, DecisionOption{Label: describeEffect(effect), Effect: &effect})
+	}
+
+	ctx.Game.askDecision(d)
+	return nil
+}
+
+// resolveChoice applies the picked effect with engine-chosen targets, as
+// for triggered abilities. Only a source still on a board can target itself;
+// one that has left keeps its keywords through d.SourceCard.
+func (g *Game) resolveChoice(ps *PlayerState, d *PendingDecision, opt DecisionOption) {
+	effect := *opt.Effect
+
+	var source *CardInstance
+	if d.Source != "" {
+		source, _ = g.findCardInstance(d.Source)
+	}
+
+	var target *TargetRef
+	if source != nil || effect.Target != cards.TargetSelf {
+		target = g.triggerTarget(effect.Target, source, ps)
+	}
+	if target == nil {
+		g.log("error", ps.PlayerID, "choice %q: target %q is gone", opt.Label, effect.Target)
+		return
+	}
+
+	g.log("choice", ps.PlayerID, "%s chose %s", ps.PlayerID, opt.Label)
+	g.runEffects([]DeferredEffect{{Effect: effect, Target: target, Caster: ps.PlayerID, Source: d.Source, SourceCard: d.SourceCard}}, source, "choice "+opt.Label)
+}
+
+// defaultDiscoverCount is how many cards discover looks at when the effect
+// gives no amount.
+const defaultDiscoverCount = 3
+
+// applyDiscover shows the target player the top cards of their deck and asks
+// them to pick one for their hand. The cards stay in the deck until then.
+func applyDiscover(ctx *EffectContext) error {
+	player, err := ctx.Game.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyDiscover: %w", err)
+	}
+
+	n := ctx.Amount
+	if n == 0 {
+		n = defaultDiscoverCount
+	}
+	n = min(n, len(player.Deck))
+	if n == 0 {
+		ctx.Game.log("discover", player.PlayerID, "%s has no cards to discover", player.PlayerID)
+		return nil
+	}
+
+	d := &PendingDecision{
+		PlayerID: player.PlayerID,
+		Kind:     DecisionDiscover,
+		Prompt:   "discover a card",
+		Count:    1,
+		Default:  []int{0},
+	}
+	if ctx.Source != nil {
+		d.Source = ctx.Source.InstanceID
+	}
+	for i := range n {
+		card := player.Deck[len(player.Deck)-1-i]
+		d.Options = append(d.Options, DecisionOption{Label: card.Def.Name, InstanceID: card.InstanceID})
+	}
+
+	ctx.Game.askDecision(d)
+	return nil
+}
+
+// resolveDiscover puts the picked card into the hand (burning it if the hand
+// is full). The others go under the deck one at a time in the order shown,
+// as with KeepHand.
+func (g *Game) resolveDiscover(ps *PlayerState, d *PendingDecision, choice int) {
+	var rest []CardInstance
+	for i, opt := range d.Options {
+		idx := deckIndex(ps, opt.InstanceID)
+		if idx < 0 {
+			g.log("error", ps.PlayerID, "discover: %s (%s) left the deck", opt.Label, opt.InstanceID)
+			continue
+		}
+		card := ps.Deck[idx]
+		ps.Deck = append(ps.Deck[:idx], ps.Deck[idx+1:]...)
+
+		if i != choice {
+			rest = append(rest, card)
+			continue
+		}
+		if g.handFull(ps) {
+			g.burnCard(ps, card)
+			continue
+		}
+		ps.Hand = append(ps.Hand, card)
+		g.logPrivate("discover", ps.PlayerID, card.Def.Name, "%s discovered a card", ps.PlayerID)
+	}
+
+	// The deck is drawn from the end, so the bottom is the front
+	slices.Reverse(rest)
+	ps.Deck = append(rest, ps.Deck...)
+}
+
+func deckIndex(ps *PlayerState, id InstanceID) int {
+	for i := range ps.Deck {
+		if ps.Deck[i].InstanceID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// askDiscard asks the player to discard n cards. Without an answer the
+// newest cards in hand go.
+func (g *Game) askDiscard(ps *PlayerState, n int) {
+	d := &PendingDecision{
+		PlayerID: ps.PlayerID,
+		Kind:     DecisionDiscard,
+		Prompt:   fmt.Sprintf("discard %d cards", n),
+		Count:    n,
+	}
+	for i, card := range ps.Hand {
+		d.Options = append(d.Options, DecisionOption{Label: card.Def.Name, InstanceID: card.InstanceID})
+		if i >= len(ps.Hand)-n {
+			d.Default = append(d.Default, i)
+		}
+	}
+	g.askDecision(d)
+}
+
+func (g *Game) resolveDiscard(ps *PlayerState, d *PendingDecision, choices []int) {
+	for _, c := range choices {
+		opt := d.Options[c]
+		idx := handIndex(ps, opt.InstanceID)
+		if idx < 0 {
+			g.log("error", ps.PlayerID, "discard: %s (%s) left the hand", opt.Label, opt.InstanceID)
+			continue
+		}
+		card := ps.Hand[idx]
+		if err := g.moveToGraveyard(&card, "discarded"); err != nil {
+			g.log("error", ps.PlayerID, "discard: %v", err)
+		}
+	}
+}
+
+func handIndex(ps *PlayerState, id InstanceID) int {
+	for i := range ps.Hand {
+		if ps.Hand[i].InstanceID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// describeEffect is the label of a choose_one option.
+func describeEffect(e cards.Effect) string {
+	switch {
+	case e.BuffAttack != 0 || e.BuffHealth != 0:
+		return fmt.Sprintf("%s %+d/%+d (%s)", e.Kind, e.BuffAttack, e.BuffHealth, e.Target)
+	case e.Amount != 0:
+		return fmt.Sprintf("%s %d (%s)", e.Kind, e.Amount, e.Target)
+	case e.Value != "":
+		return fmt.Sprintf("%s %s (%s)", e.Kind, e.Value, e.Target)
+	default:
+		return fmt.Sprintf("%s (%s)", e.Kind, e.Target)
+	}
+}
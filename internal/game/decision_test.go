@@ -0,0 +1,267 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+var (
+	chooseOneEffect = cards.Effect{Kind: cards.EffectChooseOne, Target: cards.TargetSelfPlayer, Choices: []cards.Effect{
+		{Kind: cards.EffectDrawCards, Amount: 2, Target: cards.TargetSelfPlayer},
+		{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyPlayer},
+	}}
+	discoverEffect = cards.Effect{Kind: cards.EffectDiscover, Target: cards.TargetSelfPlayer}
+)
+
+func TestDecision_ChooseOne(t *testing.T) {
+	g := newCombatGame(t)
+	castSpell(t, g, chooseOneEffect)
+
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionChooseOne, d.Kind)
+	assert.Equal(t, "p0", d.PlayerID)
+	assert.Equal(t, InstanceID("s#1"), d.Source)
+	require.Len(t, d.Options, 2)
+	assert.Equal(t, "damage 3 (enemy_player)", d.Options[1].Label)
+
+	assert.ErrorIs(t, g.EndTurn(), ErrDecisionPending)
+	assert.ErrorIs(t, g.Resolve("p1", []int{1}), ErrNotYourDecision)
+	assert.ErrorIs(t, g.Resolve("p0", []int{0, 1}), ErrChoiceCount)
+	assert.ErrorIs(t, g.Resolve("p0", []int{2}), ErrInvalidChoice)
+
+	require.NoError(t, g.Resolve("p0", []int{1}))
+	assert.Equal(t, 17, g.Players[1].Life)
+	assert.Empty(t, g.Players[0].Hand, "the other choice did not happen")
+	assert.Nil(t, g.PendingDecision())
+	require.NoError(t, g.EndTurn())
+}
+
+func TestDecision_StaleAnswer(t *testing.T) {
+	g := newCombatGame(t)
+	castSpell(t, g, chooseOneEffect)
+	id := g.PendingDecision().ID
+
+	require.NoError(t, g.Submit(DecisionTimeoutAction{DecisionID: id}))
+	assert.Len(t, g.Players[0].Hand, 2, "the default is the first choice")
+
+	// A late answer or a second timer for the same decision changes nothing
+	assert.ErrorIs(t, g.Submit(ResolveDecisionAction{PlayerID: "p0", DecisionID: id, Choices: []int{1}}), ErrNoDecisionPending)
+	assert.ErrorIs(t, g.Submit(DecisionTimeoutAction{DecisionID: id}), ErrNoDecisionPending)
+	assert.Equal(t, 20, g.Players[1].Life)
+}
+
+func TestDecision_RejectedBeforeAnyChange(t *testing.T) {
+	g := newCombatGame(t)
+	g.Draw(g.Players[0], 2)
+	g.askDiscard(g.Players[0], 1)
+	d := g.PendingDecision()
+	hand, log := collectIDs(g.Players[0].Hand), len(g.Log)
+
+	// A decision restored from a bad snapshot must fail validation, not
+	// half-way through being resolved
+	d.Kind = "sacrifice"
+	assert.ErrorIs(t, g.Submit(ResolveDecisionAction{PlayerID: "p0", DecisionID: d.ID, Choices: []int{0}}), ErrNoDecisionPending)
+	d.Kind = DecisionDiscard
+	d.Default = []int{7}
+	assert.ErrorIs(t, g.Submit(DecisionTimeoutAction{DecisionID: d.ID}), ErrInvalidChoice)
+
+	assert.Equal(t, hand, collectIDs(g.Players[0].Hand))
+	assert.Len(t, g.Log, log)
+	assert.Same(t, d, g.PendingDecision())
+	assert.Empty(t, g.History)
+}
+
+func TestDecision_PausesRemainingEffects(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	castSpell(t, g, chooseOneEffect, discoverEffect)
+
+	require.Len(t, g.Decisions, 1, "discover waits for the first answer")
+	require.Len(t, g.PendingDecision().Then, 1)
+
+	first := g.PendingDecision().ID
+	require.NoError(t, g.Resolve("p0", []int{0}))
+	require.Len(t, p0.Hand, 2)
+
+	// Discover is asked only now, so it offers cards the draw didn't take
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionDiscover, d.Kind)
+	assert.Greater(t, d.ID, first)
+	assert.Equal(t, p0.Deck[len(p0.Deck)-1].InstanceID, d.Options[0].InstanceID)
+
+	require.NoError(t, g.Resolve("p0", []int{0}))
+	assert.Nil(t, g.PendingDecision())
+	assert.Len(t, p0.Hand, 3)
+}
+
+func TestDecision_NestedChoiceKeepsOrder(t *testing.T) {
+	g := newCombatGame(t)
+	nested := cards.Effect{Kind: cards.EffectChooseOne, Target: cards.TargetSelfPlayer, Choices: []cards.Effect{
+		chooseOneEffect,
+		{Kind: cards.EffectHeal, Amount: 1, Target: cards.TargetSelfPlayer},
+	}}
+	castSpell(t, g, nested, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer})
+
+	require.NoError(t, g.Resolve("p0", []int{0}))
+	assert.Equal(t, 20, g.Players[1].Life, "the damage waits for the nested choice")
+
+	require.NoError(t, g.Resolve("p0", []int{1}))
+	assert.Equal(t, 16, g.Players[1].Life, "3 from the choice, then 1 from the spell")
+	assert.Nil(t, g.PendingDecision())
+}
+
+func TestDecision_DeferredEffectsKeepSourceKeywords(t *testing.T) {
+	for _, restore := range []bool{false, true} {
+		g := newCombatGame(t)
+		p0 := g.Players[0]
+		p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+		spell := withKeywords(spellCard("ls#1", "p0", "", chooseOneEffect,
+			cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyPlayer},
+		), cards.KeywordLifesteal)
+		p0.Hand = append(p0.Hand, spell)
+		require.NoError(t, g.PlayCard("p0", 0, make([]*TargetRef, 2)))
+
+		if restore {
+			data, err := g.MarshalSnapshot()
+			require.NoError(t, err)
+			g, err = RestoreSnapshot(data, append(smallDeck(10), *spell.Def))
+			require.NoError(t, err)
+		}
+
+		// The spell is in the graveyard by now, yet its damage still heals
+		require.NoError(t, g.Resolve("p0", []int{1}))
+		assert.Equal(t, 14, g.Players[1].Life, "restored: %v", restore)
+		assert.Equal(t, 26, g.Players[0].Life, "restored: %v", restore)
+	}
+}
+
+func TestDecision_Discover(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	top := collectIDs(p0.Deck[len(p0.Deck)-3:]) // bottom-most first
+	deckSize := len(p0.Deck)
+
+	castSpell(t, g, discoverEffect)
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	require.Len(t, d.Options, 3)
+	assert.Equal(t, InstanceID(top[2]), d.Options[0].InstanceID, "options start at the top of the deck")
+	assert.Len(t, p0.Deck, deckSize, "cards stay in the deck while the player decides")
+
+	require.NoError(t, g.Resolve("p0", []int{1}))
+	require.Len(t, p0.Hand, 1)
+	assert.Equal(t, top[1], string(p0.Hand[0].InstanceID))
+	assert.Len(t, p0.Deck, deckSize-1)
+
+	// The rest go under one at a time, so the last shown ends up deepest
+	assert.Equal(t, []string{top[0], top[2]}, collectIDs(p0.Deck[:2]))
+}
+
+func TestDecision_DiscoverEmptyDeck(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Deck = nil
+
+	castSpell(t, g, discoverEffect)
+	assert.Nil(t, g.PendingDecision())
+}
+
+func TestDecision_TriggerAtTurnEnd(t *testing.T) {
+	g, err := NewGame("p0", "p1", smallDeck(10), smallDeck(10), Options{StartingHand: 4, MaxHandSize: 3, HandLimit: HandLimitDiscard, Seed: 3})
+	require.NoError(t, err)
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board, withAbility(newCreature("o#1", "Oracle", "p0", 1, 1), cards.TriggerOnTurnEnd, chooseOneEffect))
+
+	require.NoError(t, g.StartTurn())
+	require.NoError(t, g.EndTurn())
+	assert.True(t, g.EndingTurn)
+	assert.Equal(t, DecisionChooseOne, g.PendingDecision().Kind)
+
+	// Drawing two more raises the discard from one card to three
+	require.NoError(t, g.Resolve("p0", []int{0}))
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionDiscard, d.Kind)
+	assert.Equal(t, 3, d.Count)
+	assert.Equal(t, 0, g.Active)
+
+	require.NoError(t, g.Resolve("p0", []int{0, 1, 2}))
+	assert.Len(t, p0.Hand, 3)
+	assert.False(t, g.EndingTurn)
+	assert.Equal(t, 1, g.Active)
+}
+
+func TestDecision_LegalActions(t *testing.T) {
+	g, err := NewGame("p0", "p1", makeDeck(20), makeDeck(20), Options{StartingHand: 3, MaxHandSize: 4, HandLimit: HandLimitDiscard, Seed: 11})
+	require.NoError(t, err)
+	require.NoError(t, g.StartTurn())
+	g.Draw(g.Players[0], 3)
+	require.NoError(t, g.EndTurn())
+
+	actions := g.LegalActions("p0")
+	assert.Len(t, actions, 15, "any two of six cards")
+	for _, a := range actions {
+		assert.NoError(t, a.Validate(g))
+	}
+	assert.Empty(t, g.LegalActions("p1"))
+}
+
+func TestDecision_ViewHidesOptions(t *testing.T) {
+	g := newCombatGame(t)
+	castSpell(t, g, discoverEffect)
+
+	own, err := g.ViewFor("p0")
+	require.NoError(t, err)
+	require.NotNil(t, own.Decision)
+	assert.Len(t, own.Decision.Options, 3)
+
+	other, err := g.ViewFor("p1")
+	require.NoError(t, err)
+	require.NotNil(t, other.Decision)
+	assert.Equal(t, DecisionDiscover, other.Decision.Kind)
+	assert.Empty(t, other.Decision.Options)
+	assert.Empty(t, other.Log[len(other.Log)-1].Private)
+}
+
+func TestDecision_SnapshotAndReplay(t *testing.T) {
+	defs := append(makeDeck(20), makeDeck(20)...)
+	g, err := NewGame("p0", "p1", makeDeck(20), makeDeck(20), Options{StartingHand: 3, MaxHandSize: 4, HandLimit: HandLimitDiscard, Seed: 11})
+	require.NoError(t, err)
+	require.NoError(t, g.StartTurn())
+	g.Draw(g.Players[0], 2)
+	require.NoError(t, g.EndTurn())
+	require.NotNil(t, g.PendingDecision())
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := RestoreSnapshot(data, defs)
+	require.NoError(t, err)
+
+	assert.Equal(t, g.Decisions, restored.Decisions)
+	assert.True(t, restored.EndingTurn)
+	assert.ErrorIs(t, restored.StartTurn(), ErrDecisionPending)
+
+	for _, game := range []*Game{g, restored} {
+		require.NoError(t, game.TimeoutDecision())
+		assert.Equal(t, 1, game.Active)
+	}
+	assert.Equal(t, collectIDs(g.Players[0].Graveyard), collectIDs(restored.Players[0].Graveyard))
+
+	// Decisions asked later get fresh IDs on the restored game too
+	require.NoError(t, restored.StartTurn())
+	g.Draw(restored.Players[1], 2)
+	require.NoError(t, restored.EndTurn())
+	assert.Equal(t, 2, restored.PendingDecision().ID)
+
+	data, err = MarshalAction(DecisionTimeoutAction{DecisionID: 2})
+	require.NoError(t, err)
+	var env map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &env))
+	assert.JSONEq(t, `"decision_timeout"`, string(env["type"]))
+}
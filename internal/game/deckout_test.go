@@ -0,0 +1,107 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeckOut_NoneSkipsDraw(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutNone)
+
+	require.NoError(t, g.StartTurn())
+	assert.False(t, g.GameEnded)
+	assert.Equal(t, 20, g.Players[0].Life)
+	assert.Len(t, g.Players[0].Hand, 3)
+}
+
+func TestDeckOut_Lose(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutLose)
+
+	require.NoError(t, g.StartTurn())
+	assert.True(t, g.Players[0].DeckedOut)
+	assert.True(t, g.GameEnded)
+	assert.Contains(t, g.Winner, g.Players[1].Name)
+	assert.Contains(t, lastEvent(g, "game_end").Msg, "decked out")
+}
+
+func TestDeckOut_LoseBothIsDraw(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutLose)
+
+	// Drawing effects can empty both decks in the same action
+	g.Draw(g.Players[0], 1)
+	g.Draw(g.Players[1], 1)
+	g.resolveStateBasedEffects()
+
+	assert.True(t, g.GameEnded)
+	assert.Equal(t, "Draw!", g.Winner)
+}
+
+func TestDeckOut_Fatigue(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutFatigue)
+	p1 := g.Players[0]
+
+	assert.Equal(t, 0, g.Draw(p1, 3))
+	assert.Equal(t, 3, p1.Fatigue)
+	assert.Equal(t, 20-1-2-3, p1.Life)
+
+	assert.Equal(t, 0, g.Draw(p1, 1))
+	assert.Equal(t, 4, p1.Fatigue)
+	assert.Equal(t, 20-1-2-3-4, p1.Life)
+
+	var fatigue []string
+	for _, e := range g.Log {
+		if e.Type == "fatigue" {
+			fatigue = append(fatigue, e.Msg)
+		}
+	}
+	assert.Equal(t, []string{
+		"p1 takes 1 fatigue damage",
+		"p1 takes 2 fatigue damage",
+		"p1 takes 3 fatigue damage",
+		"p1 takes 4 fatigue damage",
+	}, fatigue)
+}
+
+func TestDeckOut_FatigueKills(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutFatigue)
+	g.Players[0].Life = 1
+
+	require.NoError(t, g.StartTurn())
+	assert.True(t, g.GameEnded)
+	assert.Contains(t, lastEvent(g, "game_end").Msg, "died with 0 life")
+}
+
+func TestDeckOut_Reshuffle(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutReshuffle)
+	p1 := g.Players[0]
+
+	// Nothing to reshuffle yet: the draw is skipped
+	assert.Equal(t, 0, g.Draw(p1, 1))
+	assert.Equal(t, "deck_out", g.Log[len(g.Log)-2].Type)
+
+	p1.Graveyard = append(p1.Graveyard, p1.Hand...)
+	p1.Hand = nil
+
+	assert.Equal(t, 2, g.Draw(p1, 2))
+	assert.Len(t, p1.Hand, 2)
+	assert.Len(t, p1.Deck, 1)
+	assert.Empty(t, p1.Graveyard)
+	assert.NotNil(t, lastEvent(g, "reshuffle"))
+	assert.False(t, g.GameEnded)
+}
+
+func TestDeckOut_Snapshot(t *testing.T) {
+	g := newDeckOutGame(t, DeckOutFatigue)
+	g.Draw(g.Players[1], 2)
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := RestoreSnapshot(data, makeDeck(3))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, restored.Players[1].Fatigue)
+	restored.Draw(restored.Players[1], 1)
+	assert.Equal(t, 20-1-2-3, restored.Players[1].Life)
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func applyDestroy(ctx *EffectContext) error {
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyDestroy: %w", err)
+	}
+
+	ctx.Game.log("destroy", ctx.Caster.PlayerID, "%s (%s) destroyed", creature.Def.Name, creature.InstanceID)
+	if ctx.simultaneous {
+		creature.Destroyed = true // removed with the others by state-based effects
+		return nil
+	}
+	return ctx.Game.moveToGraveyard(creature, "destroyed by "+sourceName(ctx.Source))
+}
+
+// applyReturnToHand puts the creature back in its owner's hand as a fresh
+// copy of the card. A full hand burns it instead, and a token ceases to
+// exist.
+func applyReturnToHand(ctx *EffectContext) error {
+	g := ctx.Game
+	creature, err := g.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyReturnToHand: %w", err)
+	}
+
+	owner := g.playerByID(creature.Owner)
+	if owner == nil {
+		return fmt.Errorf("applyReturnToHand: owner %s: %w", creature.Owner, ErrPlayerNotFound)
+	}
+
+	card, ok := g.removeFromBoard(creature.InstanceID)
+	if !ok {
+		return fmt.Errorf("applyReturnToHand: %w", ErrInvalidTarget)
+	}
+	if card.Token {
+		g.log("token", ctx.Caster.PlayerID, "%s (%s) ceased to exist (returned to hand)", card.Def.Name, card.InstanceID)
+		return nil
+	}
+	resetCard(&card)
+	card.Controller = card.Owner
+
+	if g.handFull(owner) {
+		g.burnCard(owner, card)
+		return nil
+	}
+	owner.Hand = append(owner.Hand, card)
+	g.log("return_to_hand", ctx.Caster.PlayerID, "%s (%s) returned to %s's hand", card.Def.Name, card.InstanceID, owner.PlayerID)
+	return nil
+}
+
+// applySilence strips every buff but keeps damage taken, so a creature kept
+// alive by a health buff can die to state-based effects.
+func applySilence(ctx *EffectContext) error {
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applySilence: %w", err)
+	}
+
+	creature.PermAttackBuff, creature.PermHealthBuff = 0, 0
+	creature.TempAttackBuff, creature.TempHealthBuff = 0, 0
+	computeStats(creature)
+	ctx.Game.log("silence", ctx.Caster.PlayerID, "%s (%s) silenced", creature.Def.Name, creature.InstanceID)
+	return nil
+}
+
+// applyFreeze stops the creature attacking until its controller's next turn
+// is over; frozen on that turn itself, it also sits out the one after.
+func applyFreeze(ctx *EffectContext) error {
+	g := ctx.Game
+	creature, err := g.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyFreeze: %w", err)
+	}
+
+	next := g.Turn + 1
+	if creature.Controller == g.CurrentPlayer().PlayerID {
+		next = g.Turn + 2
+	}
+	creature.FrozenUntil = max(creature.FrozenUntil, next)
+	g.log("freeze", ctx.Caster.PlayerID, "%s (%s) frozen", creature.Def.Name, creature.InstanceID)
+	return nil
+}
+
+// applyTransform turns the creature into ctx.Into. It keeps its instance,
+// controller and readiness but loses buffs and damage.
+func applyTransform(ctx *EffectContext) error {
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyTransform: %w", err)
+	}
+	if ctx.Into == nil {
+		return fmt.Errorf("applyTransform: no card to transform into")
+	}
+
+	from := creature.Def.Name
+	creature.Def = ctx.Into
+	resetStats(creature)
+	creature.DivineShield = creature.Def.HasKeyword(cards.KeywordDivineShield)
+	ctx.Game.log("transform", ctx.Caster.PlayerID, "%s (%s) transformed into %s", from, creature.InstanceID, creature.Def.Name)
+	return nil
+}
+
+// resetStats drops buffs and damage and recomputes stats from the card.
+func resetStats(ci *CardInstance) {
+	ci.PermAttackBuff, ci.PermHealthBuff = 0, 0
+	ci.TempAttackBuff, ci.TempHealthBuff = 0, 0
+	ci.AuraAttackBuff, ci.AuraHealthBuff = 0, 0
+	ci.CurrentDamage = 0
+	computeStats(ci)
+}
+
+// resetCard returns a card leaving the board to the state it was in before
+// it was played.
+func resetCard(ci *CardInstance) {
+	resetStats(ci)
+	ci.SummoningSick = false
+	ci.Exhausted = false
+	ci.AttackedThisTurn = false
+	ci.DivineShield = false
+	ci.Destroyed = false
+	ci.FrozenUntil = 0
+}
+
+// removeFromBoard takes the creature off whichever board holds it.
+func (g *Game) removeFromBoard(id InstanceID) (CardInstance, bool) {
+	for _, p := range g.Players {
+		for i := range p.Board {
+			if p.Board[i].InstanceID == id {
+				card := p.Board[i]
+				p.Board = append(p.Board[:i], p.Board[i+1:]...)
+				return card, true
+			}
+		}
+	}
+	return CardInstance{}, false
+}
+
+func sourceName(source *CardInstance) string {
+	if source == nil {
+		return "an effect"
+	}
+	return source.Def.Name
+}
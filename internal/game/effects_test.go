@@ -0,0 +1,189 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+var sheep = &cards.CardDef{ID: "c_sheep", Name: "Sheep", Type: cards.TypeCreature, Attack: 1, Health: 1}
+
+func TestEffect_Destroy(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+	p1.Board = append(p1.Board,
+		newCreature("a#1", "Ant", "p1", 1, 1),
+		withAbility(newCreature("b#1", "Bomb", "p1", 1, 9), cards.TriggerOnDeath, cards.Effect{Kind: cards.EffectDamage, Amount: 2, Target: cards.TargetEnemyPlayer}),
+		newCreature("c#1", "Cat", "p1", 1, 1),
+	)
+	p1.Board[1].DivineShield = true
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetEnemyCreature}, "b#1")
+
+	assert.Equal(t, []string{"a#1", "c#1"}, collectIDs(p1.Board))
+	require.Len(t, p1.Graveyard, 1)
+	assert.Equal(t, InstanceID("b#1"), p1.Graveyard[0].InstanceID, "the destroyed card, not its neighbour, reaches the graveyard")
+	assert.Equal(t, 18, g.Players[0].Life, "on_death fires")
+}
+
+func TestEffect_ReturnToHand(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+
+	wolf := newCreature("w#1", "Wolf", "p1", 2, 3)
+	wolf.Controller = "p0" // stolen creatures go back to their owner
+	wolf.PermAttackBuff, wolf.CurrentAttack = 2, 4
+	wolf.CurrentDamage, wolf.CurrentHealth = 1, 2
+	wolf.Exhausted = true
+	p0.Board = append(p0.Board, wolf)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectReturnToHand, Target: cards.TargetAnyCreature}, "w#1")
+
+	assert.Empty(t, p0.Board)
+	require.Len(t, p1.Hand, 1)
+	card := p1.Hand[0]
+	assert.Equal(t, "p1", card.Controller)
+	assert.Equal(t, 0, card.PermAttackBuff)
+	assert.Equal(t, 0, card.CurrentDamage)
+	assert.Equal(t, 2, card.CurrentAttack)
+	assert.Equal(t, 3, card.CurrentHealth)
+	assert.False(t, card.Exhausted)
+}
+
+func TestEffect_ReturnToFullHandBurns(t *testing.T) {
+	g := newCombatGame(t)
+	g.Options.MaxHandSize = 1
+	p1 := g.Players[1]
+	g.Draw(p1, 1)
+	p1.Board = append(p1.Board, newCreature("w#1", "Wolf", "p1", 2, 3))
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectReturnToHand, Target: cards.TargetEnemyCreature}, "w#1")
+
+	assert.Len(t, p1.Hand, 1)
+	require.Len(t, p1.Graveyard, 1)
+	assert.Equal(t, InstanceID("w#1"), p1.Graveyard[0].InstanceID)
+}
+
+func TestEffect_Silence(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+
+	giant := newCreature("g#1", "Giant", "p1", 2, 2)
+	giant.PermAttackBuff, giant.TempAttackBuff, giant.CurrentAttack = 1, 1, 4
+	p1.Board = append(p1.Board, giant)
+
+	// Held up only by its health buff
+	propped := newCreature("p#1", "Propped", "p1", 1, 2)
+	propped.PermHealthBuff, propped.CurrentDamage, propped.CurrentHealth = 3, 3, 2
+	p1.Board = append(p1.Board, propped)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectSilence, Target: cards.TargetEnemyCreature}, "g#1")
+	assert.Equal(t, 2, p1.Board[0].CurrentAttack)
+	assert.Zero(t, p1.Board[0].PermAttackBuff)
+	assert.Zero(t, p1.Board[0].TempAttackBuff)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectSilence, Target: cards.TargetEnemyCreature}, "p#1")
+	assert.Equal(t, []string{"g#1"}, collectIDs(p1.Board))
+	assert.Equal(t, []string{"p#1"}, collectIDs(p1.Graveyard))
+}
+
+func TestEffect_Freeze(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 5))
+	p1.Board = append(p1.Board, newCreature("e#1", "Enemy", "p1", 1, 5))
+
+	g.TurnStarted = false
+	require.NoError(t, g.StartTurn()) // turn 1, p0
+	castAt(t, g, cards.Effect{Kind: cards.EffectFreeze, Target: cards.TargetEnemyCreature}, "e#1")
+	castAt(t, g, cards.Effect{Kind: cards.EffectFreeze, Target: cards.TargetAllyCreature}, "a#1")
+
+	view, err := g.ViewFor("p1")
+	require.NoError(t, err)
+	assert.True(t, view.Players[1].Board[0].Frozen)
+
+	// Frozen on its controller's own turn: this turn and the next are lost
+	assert.ErrorIs(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}), ErrCreatureFrozen)
+	require.NoError(t, g.EndTurn())
+
+	require.NoError(t, g.StartTurn()) // turn 2, p1
+	assert.ErrorIs(t, g.DeclareAttackers("p1", []InstanceID{"e#1"}), ErrCreatureFrozen)
+	require.NoError(t, g.EndTurn())
+
+	require.NoError(t, g.StartTurn()) // turn 3, p0
+	assert.ErrorIs(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}), ErrCreatureFrozen)
+	require.NoError(t, g.EndTurn())
+
+	require.NoError(t, g.StartTurn()) // turn 4, p1
+	assert.NoError(t, g.DeclareAttackers("p1", []InstanceID{"e#1"}))
+}
+
+func TestEffect_Transform(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+
+	ogre := withAbility(newCreature("o#1", "Ogre", "p1", 5, 5), cards.TriggerOnDeath, cards.Effect{Kind: cards.EffectDamage, Amount: 5, Target: cards.TargetEnemyPlayer})
+	ogre.PermAttackBuff, ogre.CurrentAttack = 2, 7
+	ogre.CurrentDamage, ogre.CurrentHealth = 3, 2
+	ogre.Exhausted = true
+	p1.Board = append(p1.Board, ogre)
+
+	polymorph := cards.Effect{Kind: cards.EffectTransform, Target: cards.TargetEnemyCreature, Into: sheep}
+	castAt(t, g, polymorph, "o#1")
+
+	require.Len(t, p1.Board, 1)
+	card := p1.Board[0]
+	assert.Equal(t, InstanceID("o#1"), card.InstanceID)
+	assert.Equal(t, "Sheep", card.Def.Name)
+	assert.Equal(t, 1, card.CurrentAttack)
+	assert.Equal(t, 1, card.CurrentHealth)
+	assert.Zero(t, card.PermAttackBuff)
+	assert.True(t, card.Exhausted, "readiness is kept")
+
+	// The sheep has no on_death ability any more
+	castAt(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetEnemyCreature}, "o#1")
+	assert.Equal(t, 20, g.Players[0].Life)
+}
+
+func TestEffect_TransformSnapshot(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[1].Board = append(g.Players[1].Board, newCreature("o#1", "Ogre", "p1", 5, 5))
+	polymorph := cards.Effect{Kind: cards.EffectTransform, Target: cards.TargetEnemyCreature, Into: sheep}
+	castAt(t, g, polymorph, "o#1")
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+
+	// The sheep is only defined inside the spell, which is enough to restore it
+	defs := append(smallDeck(10), *g.Players[0].Graveyard[0].Def, *newCreature("o#1", "Ogre", "p1", 5, 5).Def)
+	restored, err := RestoreSnapshot(data, defs)
+	require.NoError(t, err)
+	assert.Equal(t, "Sheep", restored.Players[1].Board[0].Def.Name)
+}
+
+func TestEffect_CreatureTargetRequired(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetEnemyCreature}))
+
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{nil}), ErrMissingTarget)
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("nope")}}), ErrInvalidTarget)
+}
+
+func TestEffect_NilTargetIsAnError(t *testing.T) {
+	kinds := []cards.EffectKind{
+		cards.EffectDamage, cards.EffectHeal, cards.EffectDrawCards, cards.EffectBuffStatsPerm,
+		cards.EffectDestroy, cards.EffectSilence, cards.EffectMill, cards.EffectScry,
+	}
+	for _, kind := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			g := newCombatGame(t)
+			err := effectResolver[kind](&EffectContext{Game: g, Caster: g.Players[0], Amount: 1})
+			assert.ErrorIs(t, err, ErrMissingTarget)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// prepareEffect evaluates the effect's condition and value for the current
+// target. It reports false, after logging why, when the effect must be
+// skipped.
+func (g *Game) prepareEffect(ctx *EffectContext, e cards.Effect, what string) bool {
+	ctx.Amount = e.Amount
+
+	if e.Condition != "" {
+		cond, err := cards.ParseCondition(e.Condition)
+		if err != nil {
+			g.log("error", ctx.Caster.PlayerID, "%s: %v", what, err)
+			return false
+		}
+		if !cond.Holds(ctx.quantity) {
+			g.log("condition", ctx.Caster.PlayerID, "%s skipped: %s is not met", what, e.Condition)
+			return false
+		}
+	}
+
+	if e.Value != "" {
+		value, err := cards.ParseValue(e.Value)
+		if err != nil {
+			g.log("error", ctx.Caster.PlayerID, "%s: %v", what, err)
+			return false
+		}
+		ctx.Amount = max(value.Eval(ctx.quantity), 0)
+	}
+	return true
+}
+
+// quantity looks up a value an expression refers to. Self is the caster
+// and enemy their opponent.
+func (ctx *EffectContext) quantity(q cards.Quantity) int {
+	g := ctx.Game
+	self, enemy := ctx.Caster, g.opponentOf(ctx.Caster)
+
+	var target *CardInstance
+	var targetPlayer *PlayerState
+	if ctx.Target != nil {
+		if ctx.Target.InstanceID != nil {
+			target, _ = g.findCardInstance(*ctx.Target.InstanceID)
+		}
+		if ctx.Target.PlayerID != "" {
+			targetPlayer = g.playerByID(ctx.Target.PlayerID)
+		}
+	}
+
+	switch q {
+	case cards.QuantityTurn:
+		return g.Turn
+	case cards.QuantitySelfLife:
+		return self.Life
+	case cards.QuantitySelfEnergy:
+		return self.CurrentEnergy
+	case cards.QuantitySelfMaxEnergy:
+		return self.MaxEnergy
+	case cards.QuantityEnemyLife:
+		return enemy.Life
+	case cards.QuantityEnemyEnergy:
+		return enemy.CurrentEnergy
+	case cards.QuantityEnemyMaxEnergy:
+		return enemy.MaxEnergy
+	case cards.QuantitySourceAttack:
+		if ctx.Source != nil {
+			return ctx.Source.CurrentAttack
+		}
+	case cards.QuantitySourceHealth:
+		if ctx.Source != nil {
+			return ctx.Source.CurrentHealth
+		}
+	case cards.QuantityTargetAttack:
+		if target != nil {
+			return target.CurrentAttack
+		}
+	case cards.QuantityTargetHealth:
+		if target != nil {
+			return target.CurrentHealth
+		}
+	case cards.QuantityTargetLife:
+		if targetPlayer != nil {
+			return targetPlayer.Life
+		}
+	case cards.QuantityAllyCreatures:
+		return len(self.Board)
+	case cards.QuantityEnemyCreatures:
+		return len(enemy.Board)
+	case cards.QuantityAllCreatures:
+		return len(self.Board) + len(enemy.Board)
+	case cards.QuantityAllyHand:
+		return len(self.Hand)
+	case cards.QuantityEnemyHand:
+		return len(enemy.Hand)
+	case cards.QuantityAllyGraveyard:
+		return len(self.Graveyard)
+	case cards.QuantityEnemyGraveyard:
+		return len(enemy.Graveyard)
+	case cards.QuantityAllyDeck:
+		return len(self.Deck)
+	case cards.QuantityEnemyDeck:
+		return len(enemy.Deck)
+	}
+	return 0
+}
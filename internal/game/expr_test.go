@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestExpr_Condition(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 1), newCreature("a#2", "Ally", "p0", 1, 1))
+	draw := cards.Effect{Kind: cards.EffectDrawCards, Amount: 1, Condition: "count(ally_creatures) >= 3", Target: cards.TargetSelfPlayer}
+
+	castSpell(t, g, draw)
+	assert.Empty(t, p0.Hand)
+	assert.NotNil(t, lastEvent(g, "condition"))
+
+	p0.Board = append(p0.Board, newCreature("a#3", "Ally", "p0", 1, 1))
+	castSpell(t, g, draw)
+	assert.Len(t, p0.Hand, 1)
+}
+
+func TestExpr_Value(t *testing.T) {
+	g := newCombatGame(t)
+	g.Draw(g.Players[0], 4)
+
+	// The spell has left the hand by the time it resolves
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDamage, Value: "cards_in_hand", Target: cards.TargetEnemyPlayer})
+	assert.Equal(t, 16, g.Players[1].Life)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectHeal, Value: "self.life - 30", Target: cards.TargetSelfPlayer})
+	assert.Equal(t, 20, g.Players[0].Life, "negative values count as 0")
+}
+
+func TestExpr_PerTarget(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Big", "p0", 5, 5))
+	p1.Board = append(p1.Board, newCreature("e#1", "Small", "p1", 2, 2), newCreature("e#2", "Huge", "p1", 7, 7))
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDestroy, Condition: "target.attack >= 5", Target: cards.TargetAllCreatures})
+
+	assert.Empty(t, p0.Board)
+	assert.Equal(t, []string{"e#1"}, collectIDs(p1.Board))
+}
+
+func TestExpr_SourceInTrigger(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	avenger := withAbility(newCreature("a#1", "Avenger", "p0", 3, 1), cards.TriggerOnDeath,
+		cards.Effect{Kind: cards.EffectDamage, Value: "source.attack * 2", Target: cards.TargetEnemyPlayer})
+	avenger.PermAttackBuff, avenger.CurrentAttack = 1, 4
+	p0.Board = append(p0.Board, avenger)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetAllyCreature}, "a#1")
+	assert.Equal(t, 12, g.Players[1].Life)
+}
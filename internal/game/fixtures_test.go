@@ -0,0 +1,245 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// Fixtures shared by the feature tests. Games come from NewGame and are then
+// set up by hand; cards are plain instances, without a registry.
+
+// newMidTurnGame starts a p0 vs p1 game on ten-card decks with nothing in
+// hand. Tests act mid-turn, as if p0 had already started it.
+func newMidTurnGame(t *testing.T, opts Options) *Game {
+	t.Helper()
+
+	opts.Seed = 42
+	g, err := NewGame("p0", "p1", smallDeck(10), smallDeck(10), opts)
+	require.NoError(t, err)
+	g.TurnStarted = true
+	return g
+}
+
+func newCombatGame(t *testing.T) *Game {
+	t.Helper()
+	return newMidTurnGame(t, Options{})
+}
+
+// newStackGame is a mid-turn game with priority passing and full energy for
+// both players.
+func newStackGame(t *testing.T) *Game {
+	t.Helper()
+
+	g := newMidTurnGame(t, Options{PriorityPassing: true})
+	for _, p := range g.Players {
+		p.MaxEnergy, p.CurrentEnergy = 10, 10
+	}
+	return g
+}
+
+var wolfToken = cards.CardDef{ID: "t_wolf", Name: "Wolf", Type: cards.TypeCreature, Attack: 2, Health: 2, Abilities: []cards.Ability{
+	{Trigger: cards.TriggerOnDeath, Effects: []cards.Effect{{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}}},
+}}
+
+// newTokenGame is a mid-turn game that can summon wolfToken.
+func newTokenGame(t *testing.T, opts Options) *Game {
+	t.Helper()
+
+	opts.Tokens = []cards.CardDef{wolfToken}
+	return newMidTurnGame(t, opts)
+}
+
+// newPregameGame starts a p1 vs p2 game on decks of deckSize cards, before
+// anyone has started a turn.
+func newPregameGame(t *testing.T, deckSize int, opts Options) *Game {
+	t.Helper()
+
+	g, err := NewGame("p1", "p2", makeDeck(deckSize), makeDeck(deckSize), opts)
+	require.NoError(t, err)
+	return g
+}
+
+// newStartedGame starts a p1 vs p2 game on decks of deckSize cards, with
+// the first turn already under way so it can be ended.
+func newStartedGame(t *testing.T, deckSize int, opts Options) *Game {
+	t.Helper()
+
+	g, err := NewGame("p1", "p2", smallDeck(deckSize), smallDeck(deckSize), opts)
+	require.NoError(t, err)
+	g.TurnStarted = true
+	return g
+}
+
+func newMulliganGame(t *testing.T, style MulliganStyle) *Game {
+	t.Helper()
+	return newPregameGame(t, 20, Options{StartingHand: 4, Seed: 99, Mulligan: style})
+}
+
+// newDeckOutGame starts a game whose players hold their whole decks, so the
+// next draw comes from an empty deck.
+func newDeckOutGame(t *testing.T, rule DeckOutRule) *Game {
+	t.Helper()
+
+	g := newPregameGame(t, 3, Options{StartingHand: 3, Seed: 5, DeckOut: rule, FirstPlayerDraws: true})
+	require.Empty(t, g.Players[0].Deck)
+	return g
+}
+
+func newHandLimitGame(t *testing.T, policy HandLimitPolicy) *Game {
+	t.Helper()
+	return newPregameGame(t, 20, Options{StartingHand: 3, MaxHandSize: 4, HandLimit: policy, Seed: 11})
+}
+
+// newCreature builds a ready creature instance for board setup.
+func newCreature(id, name, owner string, attack, health int) CardInstance {
+	return CardInstance{
+		InstanceID: InstanceID(id),
+		Def: &cards.CardDef{
+			ID:     "c_" + name,
+			Name:   name,
+			Type:   cards.TypeCreature,
+			Attack: attack,
+			Health: health,
+		},
+		Owner:         owner,
+		Controller:    owner,
+		CurrentAttack: attack,
+		CurrentHealth: health,
+	}
+}
+
+// ptrCreature returns a pointer to a copy of ci.
+func ptrCreature(ci CardInstance) *CardInstance {
+	return &ci
+}
+
+// spellCard builds a spell in the hand of its owner.
+func spellCard(id, owner string, speed cards.Speed, effects ...cards.Effect) CardInstance {
+	return CardInstance{
+		InstanceID: InstanceID(id),
+		Def: &cards.CardDef{
+			ID: "s_" + id, Name: "Spell " + id, Type: cards.TypeSpell, Cost: 1,
+			Speed:   speed,
+			Effects: effects,
+		},
+		Owner:      owner,
+		Controller: owner,
+	}
+}
+
+// withKeywords returns ci with kws as its only keywords.
+func withKeywords(ci CardInstance, kws ...cards.Keyword) CardInstance {
+	def := *ci.Def
+	def.Keywords = kws
+	ci.Def = &def
+	ci.DivineShield = def.HasKeyword(cards.KeywordDivineShield)
+	return ci
+}
+
+// withAbility returns ci with an extra triggered ability on its definition.
+func withAbility(ci CardInstance, trigger cards.Trigger, effects ...cards.Effect) CardInstance {
+	def := *ci.Def
+	def.Abilities = append(append([]cards.Ability(nil), def.Abilities...), cards.Ability{Trigger: trigger, Effects: effects})
+	ci.Def = &def
+	return ci
+}
+
+// withAura returns ci with an extra aura on its definition.
+func withAura(ci CardInstance, scope cards.AuraScope, attack, health int) CardInstance {
+	def := *ci.Def
+	def.Auras = append(append([]cards.Aura(nil), def.Auras...), cards.Aura{Scope: scope, BuffAttack: attack, BuffHealth: health})
+	ci.Def = &def
+	return ci
+}
+
+// castSpell puts a spell with the given effects into p0's hand and plays it.
+// Every effect must target a player so no targets need to be passed.
+func castSpell(t *testing.T, g *Game, effects ...cards.Effect) {
+	t.Helper()
+
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("s#1", "p0", "", effects...))
+	require.NoError(t, g.PlayCard("p0", len(p0.Hand)-1, make([]*TargetRef, len(effects))))
+}
+
+// castAt has p0 cast a single-effect spell at the creature with the given ID.
+func castAt(t *testing.T, g *Game, effect cards.Effect, target string) {
+	t.Helper()
+
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", effect))
+	require.NoError(t, g.PlayCard("p0", len(p0.Hand)-1, []*TargetRef{{InstanceID: ptrInstance(target)}}))
+}
+
+// pushDummySpell has p0 cast a harmless spell so p1 holds priority.
+func pushDummySpell(t *testing.T, g *Game) {
+	t.Helper()
+
+	p0 := g.Players[0]
+	p0.Hand = append(p0.Hand, spellCard("dummy#1", "p0", "", cards.Effect{Kind: cards.EffectHeal, Amount: 1, Target: cards.TargetSelfPlayer}))
+	require.NoError(t, g.PlayCard("p0", len(p0.Hand)-1, []*TargetRef{nil}))
+}
+
+// autoPlay drives both players for the given number of turns: play the first
+// legal card until none is left, attack with every ready creature, never block.
+// Spells on the stack are let through by both players passing.
+func autoPlay(t *testing.T, g *Game, turns int) {
+	t.Helper()
+
+	for range turns {
+		if g.GameEnded {
+			return
+		}
+		require.NoError(t, g.StartTurn())
+		player := g.CurrentPlayer()
+
+		for {
+			var play Action
+			for _, a := range g.LegalActions(player.PlayerID) {
+				if _, ok := a.(PlayCardAction); ok {
+					play = a
+					break
+				}
+			}
+			if play == nil || g.GameEnded {
+				break
+			}
+			require.NoError(t, g.Submit(play))
+			for len(g.Stack) > 0 && !g.GameEnded {
+				require.NoError(t, g.PassPriority(g.priorityPlayer().PlayerID))
+			}
+		}
+
+		var attackers []InstanceID
+		for _, ci := range player.Board {
+			if !ci.SummoningSick && !ci.Exhausted {
+				attackers = append(attackers, ci.InstanceID)
+			}
+		}
+		if len(attackers) > 0 && !g.GameEnded {
+			require.NoError(t, g.DeclareAttackers(player.PlayerID, attackers))
+			require.NoError(t, g.DeclareBlockers(g.Opponent().PlayerID, nil))
+			require.NoError(t, g.ResolveCombat(player.PlayerID))
+		}
+
+		if g.GameEnded {
+			return
+		}
+		require.NoError(t, g.EndTurn())
+	}
+}
+
+// lastEvent returns the most recent log event of the given type, or nil.
+func lastEvent(g *Game, eventType string) *Event {
+	for i := len(g.Log) - 1; i >= 0; i-- {
+		if g.Log[i].Type == eventType {
+			return &g.Log[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// graveyardTarget returns the targeted graveyard card and the player whose
+// graveyard holds it.
+func (g *Game) graveyardTarget(target *TargetRef) (*PlayerState, *CardInstance, error) {
+	if target == nil || target.InstanceID == nil {
+		return nil, nil, ErrMissingTarget
+	}
+	p, i, ok := g.findInGraveyard(*target.InstanceID)
+	if !ok {
+		return nil, nil, ErrInvalidTarget
+	}
+	return p, &p.Graveyard[i], nil
+}
+
+// applyResurrect brings a creature card back from a graveyard onto the
+// caster's board, fresh and summoning sick. Its owner does not change. With
+// a full board the card stays where it is.
+func applyResurrect(ctx *EffectContext) error {
+	g := ctx.Game
+	_, card, err := g.graveyardTarget(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyResurrect: %w", err)
+	}
+	if card.Def.Type != cards.TypeCreature {
+		return fmt.Errorf("applyResurrect: %s is not a creature: %w", card.Def.Name, ErrInvalidTarget)
+	}
+
+	caster := ctx.Caster
+	if g.Options.MaxBoardSize > 0 && len(caster.Board) >= g.Options.MaxBoardSize {
+		g.log("resurrect", caster.PlayerID, "%s not resurrected: board is full", card.Def.Name)
+		return nil
+	}
+
+	resurrected, _ := g.takeFromGraveyard(card.InstanceID)
+	resetCard(&resurrected)
+	resurrected.Controller = caster.PlayerID
+	resurrected.SummoningSick = !resurrected.Def.HasKeyword(cards.KeywordHaste)
+	resurrected.DivineShield = resurrected.Def.HasKeyword(cards.KeywordDivineShield)
+	caster.Board = append(caster.Board, resurrected)
+	g.log("resurrect", caster.PlayerID, "%s (%s) resurrected", resurrected.Def.Name, resurrected.InstanceID)
+	return nil
+}
+
+// applyRecycle puts a graveyard card into the caster's hand. As with
+// resurrect its owner does not change, so it goes back to their graveyard
+// later. A full hand burns it straight back into the graveyard.
+func applyRecycle(ctx *EffectContext) error {
+	g := ctx.Game
+	_, card, err := g.graveyardTarget(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyRecycle: %w", err)
+	}
+
+	caster := ctx.Caster
+	recycled, _ := g.takeFromGraveyard(card.InstanceID)
+	resetCard(&recycled)
+	recycled.Controller = caster.PlayerID
+	if g.handFull(caster) {
+		g.burnCard(caster, recycled)
+		return nil
+	}
+	caster.Hand = append(caster.Hand, recycled)
+	g.log("recycle", caster.PlayerID, "%s (%s) returned to %s's hand", recycled.Def.Name, recycled.InstanceID, caster.PlayerID)
+	return nil
+}
+
+// applyShuffleIntoDeck puts a graveyard card back into its owner's deck and
+// shuffles it.
+func applyShuffleIntoDeck(ctx *EffectContext) error {
+	g := ctx.Game
+	owner, card, err := g.graveyardTarget(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyShuffleIntoDeck: %w", err)
+	}
+
+	shuffled, _ := g.takeFromGraveyard(card.InstanceID)
+	resetCard(&shuffled)
+	owner.Deck = append(owner.Deck, shuffled)
+	g.shuffle(owner.Deck)
+	g.log("shuffle_into_deck", ctx.Caster.PlayerID, "%s (%s) shuffled into %s's deck", shuffled.Def.Name, shuffled.InstanceID, owner.PlayerID)
+	return nil
+}
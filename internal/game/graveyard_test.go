@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestGraveyard_Resurrect(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+
+	dead := newCreature("d#1", "Dead", "p1", 3, 4)
+	dead.PermAttackBuff, dead.CurrentAttack = 2, 5
+	dead.CurrentDamage, dead.CurrentHealth = 4, 0
+	p1.Graveyard = append(p1.Graveyard, dead, spellCard("old#1", "p1", ""))
+
+	resurrect := cards.Effect{Kind: cards.EffectResurrect, Target: cards.TargetEnemyGraveyard}
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", resurrect))
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("old#1")}}), ErrInvalidTarget, "spells can't be resurrected")
+
+	castAt(t, g, resurrect, "d#1")
+
+	assert.Equal(t, []string{"old#1"}, collectIDs(p1.Graveyard))
+	require.Len(t, p0.Board, 1)
+	card := p0.Board[0]
+	assert.Equal(t, InstanceID("d#1"), card.InstanceID)
+	assert.Equal(t, "p1", card.Owner)
+	assert.Equal(t, "p0", card.Controller)
+	assert.Equal(t, 3, card.CurrentAttack)
+	assert.Equal(t, 4, card.CurrentHealth)
+	assert.True(t, card.SummoningSick)
+}
+
+func TestGraveyard_TargetSide(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	g.Players[1].Graveyard = append(g.Players[1].Graveyard, newCreature("e#1", "Enemy", "p1", 1, 1))
+
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", cards.Effect{Kind: cards.EffectRecycle, Target: cards.TargetAllyGraveyard}))
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("e#1")}}), ErrInvalidTarget)
+	assert.ErrorIs(t, g.PlayCard("p0", 0, []*TargetRef{nil}), ErrMissingTarget)
+}
+
+func TestGraveyard_Recycle(t *testing.T) {
+	g := newCombatGame(t)
+	g.Options.MaxHandSize = 1
+	p0, p1 := g.Players[0], g.Players[1]
+
+	old := newCreature("a#1", "Ally", "p0", 2, 2)
+	old.PermHealthBuff, old.CurrentDamage = 1, 3
+	p0.Graveyard = append(p0.Graveyard, old)
+	p1.Graveyard = append(p1.Graveyard, newCreature("e#1", "Enemy", "p1", 1, 1))
+
+	recycle := cards.Effect{Kind: cards.EffectRecycle, Target: cards.TargetAllyGraveyard}
+	castAt(t, g, recycle, "a#1")
+	require.Len(t, p0.Hand, 1)
+	assert.Equal(t, InstanceID("a#1"), p0.Hand[0].InstanceID)
+	assert.Zero(t, p0.Hand[0].PermHealthBuff)
+	assert.Zero(t, p0.Hand[0].CurrentDamage)
+
+	// p0's hand is full now, so the card burns back into its graveyard
+	castAt(t, g, cards.Effect{Kind: cards.EffectRecycle, Target: cards.TargetEnemyGraveyard}, "e#1")
+	assert.Equal(t, []string{"a#1"}, collectIDs(p0.Hand))
+	assert.Equal(t, []string{"e#1"}, collectIDs(p1.Graveyard))
+}
+
+func TestGraveyard_RecycleFromEnemyGraveyard(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p1.Graveyard = append(p1.Graveyard, newCreature("e#1", "Enemy", "p1", 1, 1))
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectRecycle, Target: cards.TargetEnemyGraveyard}, "e#1")
+	assert.Empty(t, p1.Hand)
+	assert.Empty(t, p1.Graveyard)
+	require.Equal(t, []string{"e#1"}, collectIDs(p0.Hand), "the caster gets the card")
+	assert.Equal(t, "p1", p0.Hand[0].Owner)
+	assert.Equal(t, "p0", p0.Hand[0].Controller)
+
+	// Played and killed, it goes back to its owner's graveyard
+	require.NoError(t, g.PlayCard("p0", 0, nil))
+	castAt(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetAllyCreature}, "e#1")
+	assert.Equal(t, []string{"e#1"}, collectIDs(p1.Graveyard))
+}
+
+func TestGraveyard_ShuffleIntoDeck(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+	p1.Graveyard = append(p1.Graveyard, newCreature("e#1", "Enemy", "p1", 1, 1))
+	deckSize := len(p1.Deck)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectShuffleIntoDeck, Target: cards.TargetEnemyGraveyard}, "e#1")
+
+	assert.Empty(t, p1.Graveyard)
+	assert.Len(t, p1.Deck, deckSize+1)
+	assert.Contains(t, collectIDs(p1.Deck), "e#1")
+}
+
+func TestGraveyard_LegalActions(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Graveyard = append(p0.Graveyard, newCreature("a#1", "Ally", "p0", 1, 1), spellCard("old#1", "p0", ""))
+	g.Players[1].Graveyard = append(g.Players[1].Graveyard, newCreature("e#1", "Enemy", "p1", 1, 1))
+
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("fx#1", "p0", "", cards.Effect{Kind: cards.EffectResurrect, Target: cards.TargetAllyGraveyard}))
+
+	var targets []InstanceID
+	for _, a := range g.LegalActions("p0") {
+		if play, ok := a.(PlayCardAction); ok {
+			targets = append(targets, *play.Targets[0].InstanceID)
+		}
+	}
+	assert.Equal(t, []InstanceID{"a#1"}, targets)
+}
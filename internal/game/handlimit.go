@@ -0,0 +1,32 @@
+package game
+
+type HandLimitPolicy string
+
+const (
+	HandLimitBurn    HandLimitPolicy = "burn"    // cards drawn into a full hand go to the graveyard
+	HandLimitDiscard HandLimitPolicy = "discard" // the player discards down to the limit when ending their turn
+)
+
+// handFull reports whether a drawn card has to be burned instead of joining
+// the hand.
+func (g *Game) handFull(ps *PlayerState) bool {
+	if g.Options.MaxHandSize <= 0 || g.Options.HandLimit == HandLimitDiscard {
+		return false
+	}
+	return len(ps.Hand) >= g.Options.MaxHandSize
+}
+
+// burnCard puts a card drawn into a full hand straight into the graveyard.
+// Burned cards are revealed to both players.
+func (g *Game) burnCard(ps *PlayerState, card CardInstance) {
+	g.putInGraveyard(card)
+	g.log("burn", ps.PlayerID, "%s burned %s (hand full)", ps.PlayerID, card.Def.Name)
+}
+
+// excessCards is how many cards ps must discard at the end of their turn.
+func (g *Game) excessCards(ps *PlayerState) int {
+	if g.Options.MaxHandSize <= 0 || g.Options.HandLimit != HandLimitDiscard {
+		return 0
+	}
+	return max(len(ps.Hand)-g.Options.MaxHandSize, 0)
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandLimit_Burn(t *testing.T) {
+	g := newHandLimitGame(t, HandLimitBurn)
+	p1 := g.Players[0]
+	top := p1.Deck[len(p1.Deck)-3] // third card from the top
+
+	assert.Equal(t, 1, g.Draw(p1, 3), "only one card fits")
+	assert.Len(t, p1.Hand, 4)
+	require.Len(t, p1.Graveyard, 2)
+	assert.Equal(t, top.InstanceID, p1.Graveyard[1].InstanceID)
+	assert.Contains(t, lastEvent(g, "burn").Msg, top.Def.Name)
+}
+
+func TestHandLimit_DefaultPolicyBurns(t *testing.T) {
+	g := newHandLimitGame(t, "")
+	p1 := g.Players[0]
+
+	g.Draw(p1, 2)
+	assert.Len(t, p1.Hand, 4)
+	assert.Len(t, p1.Graveyard, 1)
+}
+
+func TestHandLimit_Discard(t *testing.T) {
+	g := newHandLimitGame(t, HandLimitDiscard)
+	p1 := g.Players[0]
+
+	require.NoError(t, g.StartTurn())
+	g.Draw(p1, 2)
+	require.Len(t, p1.Hand, 5, "discard mode lets the hand overflow during the turn")
+
+	require.NoError(t, g.EndTurn())
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionDiscard, d.Kind)
+	assert.Equal(t, "p1", d.PlayerID)
+	assert.Equal(t, 1, d.Count)
+	assert.Len(t, d.Options, 5)
+	assert.Equal(t, 0, g.Active, "the turn waits for the discard")
+
+	assert.ErrorIs(t, g.StartTurn(), ErrDecisionPending)
+	assert.ErrorIs(t, g.Resolve("p2", []int{0}), ErrNotYourDecision)
+	assert.ErrorIs(t, g.Resolve("p1", nil), ErrChoiceCount)
+	assert.ErrorIs(t, g.Resolve("p1", []int{5}), ErrInvalidChoice)
+
+	discarded := p1.Hand[2]
+	require.NoError(t, g.Resolve("p1", []int{2}))
+	assert.Nil(t, g.PendingDecision())
+	assert.False(t, g.EndingTurn)
+	assert.Len(t, p1.Hand, 4)
+	assert.Equal(t, discarded.InstanceID, p1.Graveyard[0].InstanceID)
+	assert.Equal(t, 1, g.Active)
+}
+
+func TestHandLimit_DiscardTimeout(t *testing.T) {
+	g := newHandLimitGame(t, HandLimitDiscard)
+	p1 := g.Players[0]
+	require.NoError(t, g.StartTurn())
+	g.Draw(p1, 3)
+	newest := collectIDs(p1.Hand[4:])
+
+	require.NoError(t, g.EndTurn())
+	require.NoError(t, g.TimeoutDecision())
+
+	assert.Equal(t, newest, collectIDs(p1.Graveyard), "the newest cards are discarded by default")
+	assert.Equal(t, 1, g.Active)
+}
+
+func TestHandLimit_DiscardNotNeeded(t *testing.T) {
+	g := newHandLimitGame(t, HandLimitDiscard)
+
+	require.NoError(t, g.StartTurn())
+	require.NoError(t, g.EndTurn())
+	assert.Nil(t, g.PendingDecision())
+	assert.Equal(t, 1, g.Active)
+}
@@ -0,0 +1,333 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestKeywords_PlayCard(t *testing.T) {
+	cases := []struct {
+		name             string
+		keywords         []cards.Keyword
+		wantSick         bool
+		wantDivineShield bool
+	}{
+		{name: "vanilla creature is summoning sick", keywords: nil, wantSick: true},
+		{name: "haste skips summoning sickness", keywords: []cards.Keyword{cards.KeywordHaste}, wantSick: false},
+		{name: "divine shield is raised on entry", keywords: []cards.Keyword{cards.KeywordDivineShield}, wantSick: true, wantDivineShield: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			p0 := g.Players[0]
+			p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+			creature := newCreature("h#1", "Charger", "p0", 2, 2)
+			creature.Def.Keywords = tc.keywords
+			p0.Hand = append(p0.Hand, creature)
+
+			require.NoError(t, g.PlayCard("p0", 0, nil))
+			require.Len(t, p0.Board, 1)
+			assert.Equal(t, tc.wantSick, p0.Board[0].SummoningSick)
+			assert.Equal(t, tc.wantDivineShield, p0.Board[0].DivineShield)
+		})
+	}
+}
+
+func TestKeywords_SpellDamage(t *testing.T) {
+	cases := []struct {
+		name          string
+		spellKeywords []cards.Keyword
+		targetShield  bool
+		amount        int
+		wantOnBoard   bool
+		wantHealth    int
+		wantCasterHP  int
+	}{
+		{
+			name:         "plain damage",
+			amount:       1,
+			wantOnBoard:  true,
+			wantHealth:   2,
+			wantCasterHP: 20,
+		},
+		{
+			name:         "divine shield absorbs the first hit",
+			targetShield: true,
+			amount:       5,
+			wantOnBoard:  true,
+			wantHealth:   3,
+			wantCasterHP: 20,
+		},
+		{
+			name:          "poisonous destroys on any damage",
+			spellKeywords: []cards.Keyword{cards.KeywordPoisonous},
+			amount:        1,
+			wantOnBoard:   false,
+			wantCasterHP:  20,
+		},
+		{
+			name:          "poisonous is stopped by divine shield",
+			spellKeywords: []cards.Keyword{cards.KeywordPoisonous},
+			targetShield:  true,
+			amount:        1,
+			wantOnBoard:   true,
+			wantHealth:    3,
+			wantCasterHP:  20,
+		},
+		{
+			name:          "lifesteal heals for damage dealt",
+			spellKeywords: []cards.Keyword{cards.KeywordLifesteal},
+			amount:        2,
+			wantOnBoard:   true,
+			wantHealth:    1,
+			wantCasterHP:  22,
+		},
+		{
+			name:          "lifesteal gains nothing through divine shield",
+			spellKeywords: []cards.Keyword{cards.KeywordLifesteal},
+			targetShield:  true,
+			amount:        2,
+			wantOnBoard:   true,
+			wantHealth:    3,
+			wantCasterHP:  20,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			p0, p1 := g.Players[0], g.Players[1]
+			p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+
+			target := newCreature("b#1", "Knight", "p1", 1, 3)
+			target.DivineShield = tc.targetShield
+			p1.Board = append(p1.Board, target)
+
+			p0.Hand = append(p0.Hand, CardInstance{
+				InstanceID: "s#1",
+				Def: &cards.CardDef{
+					ID: "s_bolt", Name: "Bolt", Type: cards.TypeSpell, Cost: 1,
+					Effects:  []cards.Effect{{Kind: cards.EffectDamage, Amount: tc.amount, Target: cards.TargetEnemyCreature}},
+					Keywords: tc.spellKeywords,
+				},
+				Owner:      "p0",
+				Controller: "p0",
+			})
+
+			require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("b#1")}}))
+
+			if tc.wantOnBoard {
+				require.Len(t, p1.Board, 1)
+				assert.Equal(t, tc.wantHealth, p1.Board[0].CurrentHealth)
+				assert.False(t, p1.Board[0].DivineShield, "shield should be gone after any hit")
+			} else {
+				assert.Empty(t, p1.Board)
+				assert.Len(t, p1.Graveyard, 1)
+			}
+			assert.Equal(t, tc.wantCasterHP, p0.Life)
+		})
+	}
+}
+
+func TestKeywords_Combat(t *testing.T) {
+	cases := []struct {
+		name          string
+		attacker      CardInstance
+		blocker       *CardInstance
+		wantAttacker  bool // attacker survives
+		wantBlocker   bool // blocker survives
+		wantExhausted bool
+		wantP0Life    int
+		wantP1Life    int
+	}{
+		{
+			name:          "vigilance attacker stays ready",
+			attacker:      withKeywords(newCreature("a#1", "Sentry", "p0", 2, 2), cards.KeywordVigilance),
+			wantAttacker:  true,
+			wantExhausted: false,
+			wantP0Life:    20,
+			wantP1Life:    18,
+		},
+		{
+			name:          "lifesteal on unblocked attacker",
+			attacker:      withKeywords(newCreature("a#1", "Leech", "p0", 3, 3), cards.KeywordLifesteal),
+			wantAttacker:  true,
+			wantExhausted: true,
+			wantP0Life:    23,
+			wantP1Life:    17,
+		},
+		{
+			name:          "poisonous attacker kills a big blocker",
+			attacker:      withKeywords(newCreature("a#1", "Viper", "p0", 1, 1), cards.KeywordPoisonous),
+			blocker:       ptrCreature(newCreature("b#1", "Giant", "p1", 1, 8)),
+			wantAttacker:  false,
+			wantBlocker:   false,
+			wantExhausted: true,
+			wantP0Life:    20,
+			wantP1Life:    20,
+		},
+		{
+			name:          "divine shield attacker survives the block",
+			attacker:      withKeywords(newCreature("a#1", "Paladin", "p0", 2, 1), cards.KeywordDivineShield),
+			blocker:       ptrCreature(newCreature("b#1", "Wolf", "p1", 3, 2)),
+			wantAttacker:  true,
+			wantBlocker:   false,
+			wantExhausted: true,
+			wantP0Life:    20,
+			wantP1Life:    20,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCombatGame(t)
+			p0, p1 := g.Players[0], g.Players[1]
+			p0.Board = append(p0.Board, tc.attacker)
+
+			blocks := map[InstanceID]InstanceID{}
+			if tc.blocker != nil {
+				p1.Board = append(p1.Board, *tc.blocker)
+				blocks[tc.attacker.InstanceID] = tc.blocker.InstanceID
+			}
+
+			require.NoError(t, g.DeclareAttackers("p0", []InstanceID{tc.attacker.InstanceID}))
+			if len(p0.Board) > 0 {
+				assert.Equal(t, tc.wantExhausted, p0.Board[0].Exhausted)
+			}
+			require.NoError(t, g.DeclareBlockers("p1", blocks))
+			require.NoError(t, g.ResolveCombat("p0"))
+
+			_, attackerAlive := g.findCardInstance(tc.attacker.InstanceID)
+			assert.Equal(t, tc.wantAttacker, attackerAlive)
+			if tc.blocker != nil {
+				_, blockerAlive := g.findCardInstance(tc.blocker.InstanceID)
+				assert.Equal(t, tc.wantBlocker, blockerAlive)
+			}
+			assert.Equal(t, tc.wantP0Life, p0.Life)
+			assert.Equal(t, tc.wantP1Life, p1.Life)
+		})
+	}
+}
+
+func TestKeywords_CombatLogShowsDamageDealt(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Wolf", "p0", 3, 5))
+	g.Players[1].Board = append(g.Players[1].Board, withKeywords(newCreature("b#1", "Paladin", "p1", 1, 5), cards.KeywordDivineShield))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#1"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	var msgs []string
+	for _, e := range g.Log {
+		if e.Type == "combat_damage" {
+			msgs = append(msgs, e.Msg)
+		}
+	}
+	assert.Equal(t, []string{"Wolf deals 0 damage to Paladin", "Paladin deals 1 damage to Wolf"}, msgs)
+}
+
+func TestKeywords_VigilanceAttacksOncePerTurn(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, withKeywords(newCreature("a#1", "Sentry", "p0", 3, 3), cards.KeywordVigilance))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+	assert.Equal(t, 17, p1.Life)
+	assert.False(t, p0.Board[0].Exhausted, "still ready to block")
+
+	assert.ErrorIs(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}), ErrAlreadyAttacked)
+	assert.Equal(t, 17, p1.Life)
+
+	// It may attack again on its controller's next turn
+	require.NoError(t, g.EndTurn())
+	require.NoError(t, g.StartTurn())
+	require.NoError(t, g.EndTurn())
+	require.NoError(t, g.StartTurn())
+	assert.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+}
+
+func TestKeywords_TauntDrawsAttacks(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board,
+		newCreature("a#1", "Bear", "p0", 2, 2),
+		newCreature("a#2", "Bear", "p0", 2, 2),
+	)
+	g.Players[1].Board = append(g.Players[1].Board,
+		withKeywords(newCreature("b#1", "Guard", "p1", 1, 4), cards.KeywordTaunt),
+		newCreature("b#2", "Wolf", "p1", 2, 2),
+	)
+
+	cases := []struct {
+		name    string
+		targets map[InstanceID]InstanceID
+		wantErr error
+	}{
+		{"attacking the player", nil, ErrMustAttackTaunt},
+		{"one attacker at the player", map[InstanceID]InstanceID{"a#1": "b#1"}, ErrMustAttackTaunt},
+		{"attacking past the taunt", map[InstanceID]InstanceID{"a#1": "b#1", "a#2": "b#2"}, ErrMustAttackTaunt},
+		{"attacking an own creature", map[InstanceID]InstanceID{"a#1": "a#2"}, ErrInvalidTarget},
+		{"target for a creature not attacking", map[InstanceID]InstanceID{"b#2": "b#1"}, ErrInvalidAttacker},
+		{"both at the taunt", map[InstanceID]InstanceID{"a#1": "b#1", "a#2": "b#1"}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeclareAttackersAction{PlayerID: "p0", Attackers: []InstanceID{"a#1", "a#2"}, Targets: tc.targets}.Validate(g)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	require.NoError(t, g.Submit(DeclareAttackersAction{PlayerID: "p0", Attackers: []InstanceID{"a#1", "a#2"}, Targets: map[InstanceID]InstanceID{"a#1": "b#1", "a#2": "b#1"}}))
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.Equal(t, 20, g.Players[1].Life, "the attacks hit the taunt, not the player")
+	assert.Equal(t, []string{"b#2"}, collectIDs(g.Players[1].Board), "two attacks kill the 1/4 guard")
+	assert.Equal(t, 1, g.Players[0].Board[0].CurrentHealth)
+	assert.Equal(t, 1, g.Players[0].Board[1].CurrentHealth)
+}
+
+func TestKeywords_ExhaustedTauntDrawsNoAttacks(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+	taunt := withKeywords(newCreature("b#1", "Guard", "p1", 1, 4), cards.KeywordTaunt)
+	taunt.Exhausted = true
+	g.Players[1].Board = append(g.Players[1].Board, taunt)
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+	assert.Equal(t, 18, g.Players[1].Life)
+}
+
+func TestKeywords_AttackOnCreatureCanBeBlocked(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 3))
+	g.Players[1].Board = append(g.Players[1].Board,
+		newCreature("b#1", "Wolf", "p1", 1, 2),
+		newCreature("b#2", "Wall", "p1", 0, 5),
+	)
+
+	require.NoError(t, g.Submit(DeclareAttackersAction{PlayerID: "p0", Attackers: []InstanceID{"a#1"}, Targets: map[InstanceID]InstanceID{"a#1": "b#1"}}))
+	view, err := g.ViewFor("p1")
+	require.NoError(t, err)
+	assert.Equal(t, map[InstanceID]InstanceID{"a#1": "b#1"}, view.Combat.Targets)
+
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#2"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.Equal(t, 2, g.Players[1].Board[0].CurrentHealth, "the blocker took the hit instead")
+	assert.Equal(t, 3, g.Players[1].Board[1].CurrentHealth)
+	assert.Equal(t, 20, g.Players[1].Life)
+}
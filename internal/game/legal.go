@@ -0,0 +1,184 @@
+package game
+
+import (
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// LegalActions lists every action the player can submit right now: each
+// playable hand card with every valid target combination, starting the turn,
+// passing priority, resolving combat and ending the turn. During the mulligan phase only the
+// player's mulligan decisions are listed, and while a decision is pending only
+// the answers to it.
+//
+// Attacker and blocker declarations are not enumerated since every subset of
+// creatures would be its own action; clients build those from the board. For
+// the same reason up_to_n targets are only listed while they stay under
+// maxUpToNSets, and larger sets are left to the client; decisions with more
+// answers than that only list their default.
+func (g *Game) LegalActions(playerID string) []Action {
+	if g.GameEnded {
+		return nil
+	}
+
+	if g.inMulligan() {
+		return g.mulliganActions(playerID)
+	}
+
+	if g.PendingDecision() != nil {
+		return g.decisionActions(playerID)
+	}
+
+	caster := g.playerByID(playerID)
+	if caster == nil {
+		return nil
+	}
+
+	var actions []Action
+
+	// Every possible target is collected once and filtered per effect
+	pool := g.targetPool()
+
+	for handIdx, card := range caster.Hand {
+		if g.checkPlayable(caster, card) != nil {
+			continue
+		}
+
+		if card.Def.Type != cards.TypeSpell {
+			actions = append(actions, PlayCardAction{PlayerID: playerID, HandIdx: handIdx})
+			continue
+		}
+
+		perEffect := make([][]*TargetRef, len(card.Def.Effects))
+		playable := true
+		for i, effect := range card.Def.Effects {
+			perEffect[i] = g.legalTargets(effect, pool, caster)
+			if len(perEffect[i]) == 0 {
+				playable = false
+				break
+			}
+		}
+		if !playable {
+			continue
+		}
+
+		for _, targets := range targetCombinations(perEffect) {
+			actions = append(actions, PlayCardAction{PlayerID: playerID, HandIdx: handIdx, Targets: targets})
+		}
+	}
+
+	for _, a := range []Action{
+		StartTurnAction{PlayerID: playerID},
+		PassPriorityAction{PlayerID: playerID},
+		ResolveCombatAction{PlayerID: playerID},
+		EndTurnAction{PlayerID: playerID},
+	} {
+		if a.Validate(g) == nil {
+			actions = append(actions, a)
+		}
+	}
+
+	return actions
+}
+
+// targetPool returns every target that any effect could point at.
+func (g *Game) targetPool() []TargetRef {
+	var pool []TargetRef
+	for _, p := range g.Players {
+		pool = append(pool, TargetRef{PlayerID: p.PlayerID})
+		for i := range p.Board {
+			pool = append(pool, TargetRef{InstanceID: &p.Board[i].InstanceID})
+		}
+		for i := range p.Graveyard {
+			pool = append(pool, TargetRef{InstanceID: &p.Graveyard[i].InstanceID})
+		}
+	}
+	for i := range g.Stack {
+		pool = append(pool, TargetRef{InstanceID: &g.Stack[i].Card.InstanceID})
+	}
+	return pool
+}
+
+// maxUpToNSets caps how many target sets are listed for one up_to_n effect,
+// since every subset of the board would otherwise be its own choice. It caps
+// the answers listed for a pending decision the same way.
+const maxUpToNSets = 64
+
+// legalTargets filters the pool down to the targets validateEffectTarget
+// accepts. Player targets are filled in automatically, so their only choice
+// is nil.
+func (g *Game) legalTargets(effect cards.Effect, pool []TargetRef, caster *PlayerState) []*TargetRef {
+	if engineTargeted(effect.Target) {
+		return []*TargetRef{nil}
+	}
+
+	// Sets of at most MaxTargets creatures, smallest first; none is passed as
+	// nil. Listing stops at the first size that would pass maxUpToNSets.
+	if effect.Target == cards.TargetUpToN {
+		var ids []InstanceID
+		for _, candidate := range pool {
+			if candidate.InstanceID == nil {
+				continue
+			}
+			if _, ok := g.findCardInstance(*candidate.InstanceID); ok {
+				ids = append(ids, *candidate.InstanceID)
+			}
+		}
+		out := []*TargetRef{nil}
+		for k := 1; k <= min(effect.MaxTargets, len(ids)); k++ {
+			if len(out)+binomial(len(ids), k) > maxUpToNSets {
+				break
+			}
+			for _, subset := range handSubsets(len(ids), k) {
+				picked := make([]InstanceID, len(subset))
+				for i, idx := range subset {
+					picked[i] = ids[idx]
+				}
+				out = append(out, &TargetRef{InstanceIDs: picked})
+			}
+		}
+		return out
+	}
+
+	if g.validateEffectTarget(effect, nil, caster) == nil {
+		return []*TargetRef{nil}
+	}
+
+	var out []*TargetRef
+	for _, candidate := range pool {
+		target := candidate
+		if target.InstanceID != nil {
+			id := *target.InstanceID
+			target.InstanceID = &id
+		}
+		if g.validateEffectTarget(effect, &target, caster) == nil {
+			out = append(out, &target)
+		}
+	}
+	return out
+}
+
+// targetCombinations returns the cartesian product of the per-effect targets.
+func targetCombinations(perEffect [][]*TargetRef) [][]*TargetRef {
+	combos := [][]*TargetRef{{}}
+	for _, options := range perEffect {
+		next := make([][]*TargetRef, 0, len(combos)*len(options))
+		for _, prefix := range combos {
+			for _, option := range options {
+				combo := make([]*TargetRef, len(prefix), len(prefix)+1)
+				copy(combo, prefix)
+				next = append(next, append(combo, option))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) int {
+	out := 1
+	for i := 1; i <= k; i++ {
+		out = out * (n - k + i) / i
+	}
+	return out
+}
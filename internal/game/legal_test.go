@@ -0,0 +1,109 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func countPlays(actions []Action, handIdx int) int {
+	n := 0
+	for _, a := range actions {
+		if play, ok := a.(PlayCardAction); ok && play.HandIdx == handIdx {
+			n++
+		}
+	}
+	return n
+}
+
+func TestLegalActions_EnumeratesTargets(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.MaxEnergy, p0.CurrentEnergy = 3, 3
+	p0.Board = append(p0.Board, newCreature("a#1", "Bear", "p0", 2, 2))
+	p1.Board = append(p1.Board,
+		newCreature("b#1", "Wolf", "p1", 2, 2),
+		newCreature("b#2", "Wolf", "p1", 2, 2),
+	)
+	p0.Hand = []CardInstance{
+		newCreature("h#0", "Cub", "p0", 1, 1),
+		spellCard("any#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetAnyCreature}),
+		spellCard("enemy#1", "p0", "",
+			cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyCreature},
+			cards.Effect{Kind: cards.EffectDrawCards, Amount: 1, Target: cards.TargetSelfPlayer},
+		),
+		spellCard("combo#1", "p0", "",
+			cards.Effect{Kind: cards.EffectBuffStatsTemp, BuffAttack: 1, Target: cards.TargetAllyCreature},
+			cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyCreature},
+		),
+		spellCard("counter#1", "p0", cards.SpeedInstant, cards.Effect{Kind: cards.EffectCounter, Target: cards.TargetStackSpell}),
+	}
+	p0.Hand[0].Def.Cost = 2
+
+	actions := g.LegalActions("p0")
+
+	assert.Equal(t, 1, countPlays(actions, 0), "creature has a single play")
+	assert.Equal(t, 3, countPlays(actions, 1), "any creature: three creatures on board")
+	assert.Equal(t, 2, countPlays(actions, 2), "enemy creature x auto self player")
+	assert.Equal(t, 2, countPlays(actions, 3), "one ally x two enemies")
+	assert.Equal(t, 0, countPlays(actions, 4), "nothing on the stack to counter")
+
+	for _, a := range actions {
+		assert.NoError(t, a.Validate(g), "%#v", a)
+	}
+
+	assert.Contains(t, actions, Action(EndTurnAction{PlayerID: "p0"}))
+	assert.NotContains(t, actions, Action(StartTurnAction{PlayerID: "p0"}), "already started")
+
+	g.TurnStarted = false
+	assert.Equal(t, []Action{StartTurnAction{PlayerID: "p0"}}, g.LegalActions("p0"), "only starting the turn before it starts")
+}
+
+func TestLegalActions_RespectsEnergyAndBoardSize(t *testing.T) {
+	g := newCombatGame(t)
+	g.Options.MaxBoardSize = 1
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 1, 1
+	p0.Board = append(p0.Board, newCreature("a#1", "Bear", "p0", 2, 2))
+	p0.Hand = []CardInstance{
+		newCreature("h#0", "Cub", "p0", 1, 1),
+		spellCard("heal#1", "p0", "", cards.Effect{Kind: cards.EffectHeal, Amount: 1, Target: cards.TargetSelfPlayer}),
+		spellCard("big#1", "p0", "", cards.Effect{Kind: cards.EffectHeal, Amount: 9, Target: cards.TargetSelfPlayer}),
+	}
+	p0.Hand[0].Def.Cost = 1
+	p0.Hand[2].Def.Cost = 2
+
+	actions := g.LegalActions("p0")
+
+	assert.Equal(t, 0, countPlays(actions, 0), "board is full")
+	assert.Equal(t, 1, countPlays(actions, 1))
+	assert.Equal(t, 0, countPlays(actions, 2), "not enough energy")
+}
+
+func TestLegalActions_OpponentAndStack(t *testing.T) {
+	g := newStackGame(t)
+	p1 := g.Players[1]
+	p1.Hand = []CardInstance{
+		newCreature("h#0", "Cub", "p1", 1, 1),
+		spellCard("counter#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectCounter, Target: cards.TargetStackSpell}),
+	}
+
+	assert.Empty(t, g.LegalActions("p1"), "waiting player can do nothing on an empty stack")
+
+	pushDummySpell(t, g)
+	actions := g.LegalActions("p1")
+
+	require.Len(t, actions, 2)
+	assert.Equal(t, 1, countPlays(actions, 1), "counter the dummy spell")
+	assert.Contains(t, actions, Action(PassPriorityAction{PlayerID: "p1"}))
+	assert.Empty(t, g.LegalActions("p0"), "p0 has no priority")
+}
+
+func TestLegalActions_GameOver(t *testing.T) {
+	g := newCombatGame(t)
+	g.GameEnded = true
+	assert.Empty(t, g.LegalActions("p0"))
+}
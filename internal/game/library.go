@@ -0,0 +1,203 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+)
+
+// applyMill puts the top ctx.Amount cards of the target player's deck into
+// their graveyard. Milling an empty deck does nothing; it is not a draw.
+func applyMill(ctx *EffectContext) error {
+	g := ctx.Game
+	player, err := g.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyMill: %w", err)
+	}
+
+	for range ctx.Amount {
+		if len(player.Deck) == 0 {
+			g.log("mill", player.PlayerID, "%s has no cards left to mill", player.PlayerID)
+			break
+		}
+		card := player.Deck[len(player.Deck)-1]
+		if err := g.moveToGraveyard(&card, "milled"); err != nil {
+			return fmt.Errorf("applyMill: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyDiscard makes the target player discard ctx.Amount cards, picked
+// with the game's random source or by the player.
+func applyDiscard(ctx *EffectContext) error {
+	g := ctx.Game
+	player, err := g.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyDiscard: %w", err)
+	}
+
+	n := min(ctx.Amount, len(player.Hand))
+	if n == 0 {
+		g.log("discard", player.PlayerID, "%s has no cards to discard", player.PlayerID)
+		return nil
+	}
+
+	if !ctx.Random {
+		g.askDiscard(player, n)
+		return nil
+	}
+	for range n {
+		card := player.Hand[g.Rand.Intn(len(player.Hand))]
+		if err := g.moveToGraveyard(&card, "discarded at random"); err != nil {
+			return fmt.Errorf("applyDiscard: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyScry shows the target player the top ctx.Amount cards of their deck.
+// They pick the ones to keep on top, in order; the rest go to the bottom.
+// Without an answer the order is left as it is.
+func applyScry(ctx *EffectContext) error {
+	g := ctx.Game
+	player, err := g.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyScry: %w", err)
+	}
+
+	n := min(ctx.Amount, len(player.Deck))
+	if n == 0 {
+		g.log("scry", player.PlayerID, "%s has no cards to scry", player.PlayerID)
+		return nil
+	}
+
+	d := &PendingDecision{
+		PlayerID: player.PlayerID,
+		Kind:     DecisionScry,
+		Prompt:   fmt.Sprintf("scry %d: pick the cards to keep on top, top first", n),
+		Count:    n,
+		UpTo:     true,
+	}
+	if ctx.Source != nil {
+		d.Source = ctx.Source.InstanceID
+	}
+	for i := range n {
+		card := player.Deck[len(player.Deck)-1-i]
+		d.Options = append(d.Options, DecisionOption{Label: card.Def.Name, InstanceID: card.InstanceID})
+		d.Default = append(d.Default, i)
+	}
+
+	g.askDecision(d)
+	return nil
+}
+
+// resolveScry puts the kept cards back on top, the first one topmost. The
+// others go under the deck one at a time in the order shown, as with
+// discover.
+func (g *Game) resolveScry(ps *PlayerState, d *PendingDecision, choices []int) {
+	shown := make([]*CardInstance, len(d.Options))
+	for i, opt := range d.Options {
+		idx := deckIndex(ps, opt.InstanceID)
+		if idx < 0 {
+			g.log("error", ps.PlayerID, "scry: %s (%s) left the deck", opt.Label, opt.InstanceID)
+			continue
+		}
+		card := ps.Deck[idx]
+		shown[i] = &card
+		ps.Deck = append(ps.Deck[:idx], ps.Deck[idx+1:]...)
+	}
+
+	var top, bottom []CardInstance
+	for _, c := range choices {
+		if shown[c] != nil {
+			top = append(top, *shown[c])
+		}
+	}
+	for i, card := range shown {
+		if card != nil && !slices.Contains(choices, i) {
+			bottom = append(bottom, *card)
+		}
+	}
+
+	// The deck is drawn from the end, so the top is the back and the bottom
+	// is the front
+	slices.Reverse(top)
+	slices.Reverse(bottom)
+	ps.Deck = append(append(bottom, ps.Deck...), top...)
+	g.log("scry", ps.PlayerID, "%s kept %d cards on top and put %d on the bottom", ps.PlayerID, len(top), len(bottom))
+}
+
+// applyTutor shows the target player the cards in their deck matching
+// ctx.Filter, top first, and asks them to pick ctx.Amount (at least one) for
+// their hand. Without an answer the topmost matches are taken.
+func applyTutor(ctx *EffectContext) error {
+	g := ctx.Game
+	player, err := g.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyTutor: %w", err)
+	}
+
+	d := &PendingDecision{
+		PlayerID: player.PlayerID,
+		Kind:     DecisionChooseCards,
+	}
+	if ctx.Source != nil {
+		d.Source = ctx.Source.InstanceID
+	}
+	for i := len(player.Deck) - 1; i >= 0; i-- {
+		card := player.Deck[i]
+		if ctx.Filter.Matches(card.Def) {
+			d.Options = append(d.Options, DecisionOption{Label: card.Def.Name, InstanceID: card.InstanceID})
+		}
+	}
+	if len(d.Options) == 0 {
+		g.log("tutor", player.PlayerID, "%s found no matching card", player.PlayerID)
+		return nil
+	}
+
+	d.Count = min(max(ctx.Amount, 1), len(d.Options))
+	d.Prompt = fmt.Sprintf("tutor: pick %d cards for your hand", d.Count)
+	for i := range d.Count {
+		d.Default = append(d.Default, i)
+	}
+
+	g.askDecision(d)
+	return nil
+}
+
+// resolveTutor puts the picked cards into the hand (burning them if the hand
+// is full), then shuffles the deck so where they were tells nothing.
+func (g *Game) resolveTutor(ps *PlayerState, d *PendingDecision, choices []int) {
+	for _, c := range choices {
+		opt := d.Options[c]
+		idx := deckIndex(ps, opt.InstanceID)
+		if idx < 0 {
+			g.log("error", ps.PlayerID, "tutor: %s (%s) left the deck", opt.Label, opt.InstanceID)
+			continue
+		}
+		card := ps.Deck[idx]
+		ps.Deck = append(ps.Deck[:idx], ps.Deck[idx+1:]...)
+		if g.handFull(ps) {
+			g.burnCard(ps, card)
+			continue
+		}
+		ps.Hand = append(ps.Hand, card)
+		g.logPrivate("tutor", ps.PlayerID, card.Def.Name, "%s searched their deck for a card", ps.PlayerID)
+	}
+
+	g.shuffle(ps.Deck)
+	g.log("shuffle", ps.PlayerID, "%s's deck was shuffled", ps.PlayerID)
+}
+
+// applyShuffle shuffles the target player's deck with the game's random
+// source.
+func applyShuffle(ctx *EffectContext) error {
+	player, err := ctx.Game.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyShuffle: %w", err)
+	}
+
+	ctx.Game.shuffle(player.Deck)
+	ctx.Game.log("shuffle", player.PlayerID, "%s's deck was shuffled", player.PlayerID)
+	return nil
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestLibrary_Mill(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+	top := collectIDs(p1.Deck[len(p1.Deck)-2:])
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectMill, Amount: 2, Target: cards.TargetEnemyPlayer})
+	assert.Len(t, p1.Deck, 8)
+	assert.Equal(t, []string{top[1], top[0]}, collectIDs(p1.Graveyard), "the top card is milled first")
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectMill, Amount: 20, Target: cards.TargetEnemyPlayer})
+	assert.Empty(t, p1.Deck)
+	assert.Len(t, p1.Graveyard, 10)
+	assert.False(t, g.GameEnded, "milling is not drawing")
+}
+
+func TestLibrary_DiscardRandom(t *testing.T) {
+	discarded := func() []string {
+		g := newCombatGame(t)
+		g.Draw(g.Players[1], 5)
+		castSpell(t, g, cards.Effect{Kind: cards.EffectDiscard, Amount: 2, Random: true, Target: cards.TargetEnemyPlayer})
+		assert.Len(t, g.Players[1].Hand, 3)
+		return collectIDs(g.Players[1].Graveyard)
+	}
+
+	first := discarded()
+	assert.Len(t, first, 2)
+	assert.Equal(t, first, discarded(), "the same seed discards the same cards")
+}
+
+func TestLibrary_DiscardChosen(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+	g.Draw(p1, 3)
+	hand := collectIDs(p1.Hand)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectDiscard, Amount: 2, Target: cards.TargetEnemyPlayer})
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionDiscard, d.Kind)
+	assert.Equal(t, "p1", d.PlayerID)
+	assert.Equal(t, 2, d.Count)
+
+	require.NoError(t, g.Resolve("p1", []int{0, 2}))
+	assert.Equal(t, []string{hand[1]}, collectIDs(p1.Hand))
+}
+
+func TestLibrary_Scry(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	top := collectIDs(p0.Deck[len(p0.Deck)-3:]) // bottom-most first
+	deckSize := len(p0.Deck)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectScry, Amount: 3, Target: cards.TargetSelfPlayer})
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionScry, d.Kind)
+	assert.True(t, d.UpTo)
+	assert.Equal(t, []int{0, 1, 2}, d.Default)
+	assert.Equal(t, InstanceID(top[2]), d.Options[0].InstanceID)
+	assert.Len(t, g.LegalActions("p0"), 16, "every ordered pick of up to three cards")
+
+	assert.ErrorIs(t, g.Resolve("p0", []int{0, 1, 2, 0}), ErrChoiceCount)
+	assert.ErrorIs(t, g.Resolve("p0", []int{1, 1}), ErrInvalidChoice)
+
+	// Keep the third card shown on top of the first; bottom the second
+	require.NoError(t, g.Resolve("p0", []int{2, 0}))
+	assert.Len(t, p0.Deck, deckSize)
+	assert.Equal(t, []string{top[2], top[0]}, collectIDs(p0.Deck[len(p0.Deck)-2:]))
+	assert.Equal(t, top[1], string(p0.Deck[0].InstanceID))
+}
+
+func TestLibrary_ScryLargeLegalActions(t *testing.T) {
+	g := newCombatGame(t)
+	require.Len(t, g.Players[0].Deck, 10)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectScry, Amount: 10, Target: cards.TargetSelfPlayer})
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+
+	// Every ordered pick of up to ten cards would be nearly ten million actions
+	actions := g.LegalActions("p0")
+	assert.Equal(t, []Action{
+		ResolveDecisionAction{PlayerID: "p0", DecisionID: d.ID, Choices: d.Default},
+		ResolveDecisionAction{PlayerID: "p0", DecisionID: d.ID},
+	}, actions)
+	for _, a := range actions {
+		assert.NoError(t, a.Validate(g))
+	}
+}
+
+func TestLibrary_ScryBottomAll(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	top := collectIDs(p0.Deck[len(p0.Deck)-2:])
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectScry, Amount: 2, Target: cards.TargetSelfPlayer})
+	require.NoError(t, g.Resolve("p0", nil))
+
+	// Shown top first, put under one at a time: the last shown ends up deepest
+	assert.Equal(t, []string{top[0], top[1]}, collectIDs(p0.Deck[:2]))
+}
+
+func TestLibrary_Tutor(t *testing.T) {
+	tutored := func() *Game {
+		g, err := NewGame("p0", "p1", makeDeck(12), makeDeck(12), Options{Seed: 5})
+		require.NoError(t, err)
+		require.NoError(t, g.StartTurn())
+		spells := cards.Effect{Kind: cards.EffectTutor, Amount: 2, Target: cards.TargetSelfPlayer, Filter: &cards.CardFilter{Type: cards.TypeSpell}}
+		castSpell(t, g, spells)
+		return g
+	}
+
+	g := tutored()
+	p0 := g.Players[0]
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, DecisionChooseCards, d.Kind)
+	assert.Equal(t, 2, d.Count)
+	assert.Equal(t, []int{0, 1}, d.Default)
+	require.Len(t, d.Options, 4, "every spell in the deck is shown")
+	for _, opt := range d.Options {
+		idx := deckIndex(p0, opt.InstanceID)
+		require.GreaterOrEqual(t, idx, 0)
+		assert.Equal(t, cards.TypeSpell, p0.Deck[idx].Def.Type)
+	}
+	assert.Len(t, g.LegalActions("p0"), 6, "every pair of spells")
+
+	picked := []string{string(d.Options[3].InstanceID), string(d.Options[1].InstanceID)}
+	require.NoError(t, g.Resolve("p0", []int{3, 1}))
+	assert.Equal(t, picked, collectIDs(p0.Hand))
+	assert.Len(t, p0.Deck, 10)
+
+	// The deck is shuffled afterwards, the same way for the same seed
+	again := tutored()
+	require.NoError(t, again.Resolve("p0", []int{3, 1}))
+	assert.Equal(t, collectIDs(p0.Deck), collectIDs(again.Players[0].Deck))
+}
+
+func TestLibrary_TutorTimeout(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Deck = append(p0.Deck, spellCard("s#1", "p0", "", cards.Effect{Kind: cards.EffectDrawCards, Amount: 1}))
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectTutor, Amount: 3, Target: cards.TargetSelfPlayer, Filter: &cards.CardFilter{Type: cards.TypeSpell}})
+	d := g.PendingDecision()
+	require.NotNil(t, d)
+	assert.Equal(t, 1, d.Count, "no more cards than there are matches")
+
+	require.NoError(t, g.TimeoutDecision())
+	assert.Equal(t, []string{"s#1"}, collectIDs(p0.Hand))
+	assert.NotContains(t, collectIDs(p0.Deck), "s#1")
+}
+
+func TestLibrary_TutorNoMatch(t *testing.T) {
+	g := newCombatGame(t)
+	deckSize := len(g.Players[0].Deck)
+
+	castSpell(t, g, cards.Effect{Kind: cards.EffectTutor, Target: cards.TargetSelfPlayer, Filter: &cards.CardFilter{Type: cards.TypeSpell}})
+	assert.Empty(t, g.Players[0].Hand)
+	assert.Len(t, g.Players[0].Deck, deckSize)
+}
+
+func TestLibrary_Shuffle(t *testing.T) {
+	shuffled := func() []string {
+		g := newCombatGame(t)
+		castSpell(t, g, cards.Effect{Kind: cards.EffectShuffle, Target: cards.TargetEnemyPlayer})
+		return collectIDs(g.Players[1].Deck)
+	}
+
+	g := newCombatGame(t)
+	before := collectIDs(g.Players[1].Deck)
+	after := shuffled()
+	assert.ElementsMatch(t, before, after)
+	assert.NotEqual(t, before, after)
+	assert.Equal(t, after, shuffled())
+}
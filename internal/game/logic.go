@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/AdonaIsium/tcg-engine/internal/cards"
@@ -18,6 +19,29 @@ func NewGame(p1ID, p2ID string, d1, d2 []cards.CardDef, opts Options) (*Game, er
 		return nil, errors.New("both players must provide a non-empty deck")
 	}
 
+	// Decks may come straight from a client, so no card is trusted
+	if err := errors.Join(validateDeckCards(p1ID, d1), validateDeckCards(p2ID, d2)); err != nil {
+		return nil, err
+	}
+
+	if opts.DeckRules != nil {
+		var errs []error
+		if err := opts.DeckRules.ValidateDeck(d1); err != nil {
+			errs = append(errs, fmt.Errorf("player %s deck: %w", p1ID, err))
+		}
+		if err := opts.DeckRules.ValidateDeck(d2); err != nil {
+			errs = append(errs, fmt.Errorf("player %s deck: %w", p2ID, err))
+		}
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+	}
+
+	if err := checkTokens(opts.Tokens, d1, d2); err != nil {
+		return nil, err
+	}
+	opts.Tokens = slices.Clone(opts.Tokens)
+
 	if opts.StartingLife <= 0 {
 		opts.StartingLife = 20
 	}
@@ -28,61 +52,26 @@ func NewGame(p1ID, p2ID string, d1, d2 []cards.CardDef, opts Options) (*Game, er
 		opts.MaxEnergy = 10
 	}
 
-	seed := opts.Seed
-	if seed == 0 {
-		seed = time.Now().UnixNano()
-	}
-
-	r := rand.New(rand.NewSource(seed))
-
-	var nextInstance int64
-	newInstanceID := func(base string) InstanceID {
-		nextInstance++
-		return InstanceID(fmt.Sprintf("%s#%d", base, nextInstance))
-	}
-
-	toInstances := func(playerID string, defs []cards.CardDef) []CardInstance {
-		out := make([]CardInstance, 0, len(defs))
-		for i := range defs {
-			def := &defs[i]
-			inst := CardInstance{
-				InstanceID:    newInstanceID(def.ID),
-				Def:           def,
-				Owner:         playerID,
-				Controller:    playerID,
-				CurrentAttack: def.Attack,
-				CurrentHealth: def.Health,
-				SummoningSick: false,
-				Exhausted:     false,
-			}
-			out = append(out, inst)
-		}
-		return out
+	// The resolved seed is kept in Options so the game can be replayed
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
 	}
+	seed := opts.Seed
+
+	src := newCountingSource(seed)
+	r := rand.New(src)
 
 	shuffle := func(insts []CardInstance) {
 		r.Shuffle(len(insts), func(i, j int) { insts[i], insts[j] = insts[j], insts[i] })
 	}
 
-	drawN := func(ps *PlayerState, n int) {
-		for range n {
-			if len(ps.Deck) == 0 {
-				break
-			}
-			top := len(ps.Deck) - 1
-			card := ps.Deck[top]
-			ps.Deck = ps.Deck[:top]
-			ps.Hand = append(ps.Hand, card)
-		}
-	}
-
 	// TODO: In the future, accept player names as parameters to NewGame
 	// For now, use default names for display purposes
 	p0 := &PlayerState{
 		PlayerID:      p1ID,
 		Name:          "Player 1", // Default name - TODO: make this configurable
 		Life:          opts.StartingLife,
-		Deck:          toInstances(p1ID, d1),
+		Deck:          nil,
 		Hand:          nil,
 		Board:         nil,
 		Graveyard:     nil,
@@ -93,7 +82,7 @@ func NewGame(p1ID, p2ID string, d1, d2 []cards.CardDef, opts Options) (*Game, er
 		PlayerID:      p2ID,
 		Name:          "Player 2", // Default name - TODO: make this configurable
 		Life:          opts.StartingLife,
-		Deck:          toInstances(p2ID, d2),
+		Deck:          nil,
 		Hand:          nil,
 		Board:         nil,
 		Graveyard:     nil,
@@ -101,18 +90,16 @@ func NewGame(p1ID, p2ID string, d1, d2 []cards.CardDef, opts Options) (*Game, er
 		MaxEnergy:     0,
 	}
 
-	shuffle(p0.Deck)
-	shuffle(p1.Deck)
-
 	g := &Game{
-		ID:      fmt.Sprintf("g_%08x", r.Uint64()),
 		Players: [2]*PlayerState{p0, p1},
 		Active:  0,
 		Turn:    0,
 		Options: opts,
-		Rand:    &randAdapter{r: r},
+		Rand:    &randAdapter{r: r, src: src},
 		Log:     nil,
 
+		decks: [2][]cards.CardDef{slices.Clone(d1), slices.Clone(d2)},
+
 		// Initialize game state
 		GameEnded: false,
 		Winner:    "",
@@ -120,27 +107,88 @@ func NewGame(p1ID, p2ID string, d1, d2 []cards.CardDef, opts Options) (*Game, er
 		// Initialize combat state
 		CombatPhase:   PhaseNone,
 		AttackingIDs:  make([]InstanceID, 0),
+		AttackTargets: make(map[InstanceID]InstanceID),
 		BlockingPairs: make(map[InstanceID]InstanceID),
 	}
 
+	p0.Deck = g.newInstances(p1ID, d1)
+	p1.Deck = g.newInstances(p2ID, d2)
+	shuffle(p0.Deck)
+	shuffle(p1.Deck)
+	g.ID = fmt.Sprintf("g_%08x", r.Uint64())
+
 	if opts.StartingHand > 0 {
-		drawN(p0, opts.StartingHand)
-		drawN(p1, opts.StartingHand)
+		g.dealCards(p0, opts.StartingHand)
+		g.dealCards(p1, opts.StartingHand)
 	}
 
 	g.Log = append(g.Log,
 		Event{Turn: g.Turn, Player: "", Type: "init", Msg: "game created"},
-		Event{Turn: g.Turn, Player: p0.PlayerID, Type: "draw", Msg: fmt.Sprintf("opening hand: %d", len(p0.Hand))},
-		Event{Turn: g.Turn, Player: p1.PlayerID, Type: "draw", Msg: fmt.Sprintf("opening hand: %d", len(p1.Hand))},
+		Event{Turn: g.Turn, Player: p0.PlayerID, Type: "draw", Msg: fmt.Sprintf("opening hand: %d", len(p0.Hand)), Private: cardNames(p0.Hand)},
+		Event{Turn: g.Turn, Player: p1.PlayerID, Type: "draw", Msg: fmt.Sprintf("opening hand: %d", len(p1.Hand)), Private: cardNames(p1.Hand)},
 	)
 
 	return g, nil
 }
 
+// validateDeckCards checks every card of the player's deck on its own and
+// returns the first problem found.
+func validateDeckCards(playerID string, deck []cards.CardDef) error {
+	for i := range deck {
+		if err := deck[i].Validate(); err != nil {
+			return fmt.Errorf("player %s deck: %w", playerID, err)
+		}
+	}
+	return nil
+}
+
 type randAdapter struct {
-	r *rand.Rand
+	r   *rand.Rand
+	src *countingSource
 }
 
 func (ra *randAdapter) Intn(n int) int {
 	return ra.r.Intn(n)
 }
+
+func (ra *randAdapter) Shuffle(n int, swap func(i, j int)) {
+	ra.r.Shuffle(n, swap)
+}
+
+// countingSource wraps the seeded source and counts how many values were
+// drawn, so the exact RNG position can be saved and restored later.
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	calls uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// restoreCountingSource rebuilds a source at the position reached after the
+// given number of draws.
+func restoreCountingSource(seed int64, calls uint64) *countingSource {
+	s := newCountingSource(seed)
+	for range calls {
+		s.Int63()
+	}
+	return s
+}
+
+func (s *countingSource) Int63() int64 {
+	s.calls++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.calls++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.calls = 0
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,3 +155,22 @@ func TestNewGame_InstanceIDUniqueness(t *testing.T) {
 		assert.Contains(t, id, "#", "InstanceID should contain '#': %s", id)
 	}
 }
+
+func TestNewGame_DeckRules(t *testing.T) {
+	rules := &cards.DeckRules{MinSize: 10, MaxCopies: 2}
+
+	_, err := NewGame("p1", "p2", makeDeck(10), makeDeck(10), Options{DeckRules: rules, Seed: 1})
+	require.NoError(t, err)
+
+	// Both decks are checked and every violation is reported
+	copies := slices.Concat(makeDeck(9), makeDeck(3), makeDeck(3))
+	_, err = NewGame("p1", "p2", makeDeck(5), copies, Options{DeckRules: rules, Seed: 1})
+	require.ErrorIs(t, err, cards.ErrDeckInvalid)
+	assert.Contains(t, err.Error(), "player p1 deck: min_size")
+	assert.NotContains(t, err.Error(), "player p2 deck: min_size")
+	assert.Contains(t, err.Error(), "player p2 deck: max_copies")
+
+	// Without rules any non-empty deck is accepted
+	_, err = NewGame("p1", "p2", makeDeck(1), copies, Options{Seed: 1})
+	require.NoError(t, err)
+}
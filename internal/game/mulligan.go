@@ -0,0 +1,267 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type MulliganStyle string
+
+const (
+	MulliganNone      MulliganStyle = ""           // keep the opening hand as dealt
+	MulliganLondon    MulliganStyle = "london"     // redraw the full hand, then bottom one card per mulligan
+	MulliganFreeFirst MulliganStyle = "free_first" // London, but the first mulligan costs nothing
+	MulliganPartial   MulliganStyle = "partial"    // replace chosen cards once (Hearthstone style)
+)
+
+var (
+	ErrMulliganPending = errors.New("mulligan phase in progress")
+	ErrNotInMulligan   = errors.New("not in the mulligan phase")
+	ErrHandKept        = errors.New("hand already kept")
+	ErrMulliganLimit   = errors.New("no mulligans left")
+	ErrBottomCount     = errors.New("wrong number of cards to put on the bottom")
+)
+
+// inMulligan reports whether the pre-game mulligan phase is still running.
+// Both players decide in any order; the phase ends once both have kept.
+func (g *Game) inMulligan() bool {
+	if g.Options.Mulligan == MulliganNone {
+		return false
+	}
+	return !g.Players[0].HandKept || !g.Players[1].HandKept
+}
+
+// mulliganBottomCount is how many cards a player who took the given number
+// of mulligans must put on the bottom of their deck under the London rules.
+func (g *Game) mulliganBottomCount(mulligans int) int {
+	switch g.Options.Mulligan {
+	case MulliganLondon:
+		return mulligans
+	case MulliganFreeFirst:
+		return max(mulligans-1, 0)
+	}
+	return 0
+}
+
+func isMulliganAction(a Action) bool {
+	switch a.(type) {
+	case MulliganAction, KeepHandAction:
+		return true
+	}
+	return false
+}
+
+// checkMulligan validates the parts every mulligan decision shares.
+func (g *Game) checkMulligan(playerID string) (*PlayerState, error) {
+	if !g.inMulligan() {
+		return nil, ErrNotInMulligan
+	}
+
+	ps := g.playerByID(playerID)
+	if ps == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	if ps.HandKept {
+		return nil, ErrHandKept
+	}
+
+	return ps, nil
+}
+
+// checkHandIndexes rejects out-of-range and repeated hand indexes.
+func checkHandIndexes(ps *PlayerState, idxs []int) error {
+	seen := make(map[int]bool, len(idxs))
+	for _, idx := range idxs {
+		if idx < 0 || idx >= len(ps.Hand) || seen[idx] {
+			return fmt.Errorf("%w: %d", ErrInvalidHandIndex, idx)
+		}
+		seen[idx] = true
+	}
+	return nil
+}
+
+// MulliganAction redraws part or all of the opening hand. Under the London
+// styles HandIdxs must be empty and the whole hand is shuffled back and
+// redrawn; the player may do this again. Under the partial style HandIdxs
+// picks the cards to replace and the hand is kept afterwards.
+type MulliganAction struct {
+	PlayerID string `json:"player_id"`
+	HandIdxs []int  `json:"hand_idxs,omitempty"`
+}
+
+func (a MulliganAction) Validate(g *Game) error {
+	ps, err := g.checkMulligan(a.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	if g.Options.Mulligan == MulliganPartial {
+		return checkHandIndexes(ps, a.HandIdxs)
+	}
+
+	if len(a.HandIdxs) > 0 {
+		return fmt.Errorf("%w: %s mulligans redraw the whole hand", ErrInvalidHandIndex, g.Options.Mulligan)
+	}
+
+	// Another mulligan must still leave at least one card after bottoming
+	if g.mulliganBottomCount(ps.Mulligans+1) >= g.Options.StartingHand {
+		return ErrMulliganLimit
+	}
+
+	return nil
+}
+
+func (a MulliganAction) Apply(g *Game) error {
+	ps := g.playerByID(a.PlayerID)
+	ps.Mulligans++
+
+	if g.Options.Mulligan == MulliganPartial {
+		g.replaceCards(ps, a.HandIdxs)
+		ps.HandKept = true
+		g.logPrivate("mulligan", ps.PlayerID, cardNames(ps.Hand), "%s replaced %d cards", ps.PlayerID, len(a.HandIdxs))
+		g.endMulligan()
+		return nil
+	}
+
+	n := len(ps.Hand)
+	ps.Deck = append(ps.Deck, ps.Hand...)
+	ps.Hand = nil
+	g.shuffle(ps.Deck)
+	g.dealCards(ps, n)
+
+	g.logPrivate("mulligan", ps.PlayerID, cardNames(ps.Hand), "%s mulligans (%d)", ps.PlayerID, ps.Mulligans)
+	return nil
+}
+
+// replaceCards swaps the cards at idxs for fresh draws in the same hand
+// positions. The new cards are drawn before the old ones are shuffled back,
+// so a replaced card can't come straight back.
+func (g *Game) replaceCards(ps *PlayerState, idxs []int) {
+	if len(idxs) == 0 {
+		return
+	}
+
+	var returned []CardInstance
+	for _, idx := range idxs {
+		if len(ps.Deck) == 0 {
+			break
+		}
+		top := len(ps.Deck) - 1
+		returned = append(returned, ps.Hand[idx])
+		ps.Hand[idx] = ps.Deck[top]
+		ps.Deck = ps.Deck[:top]
+	}
+
+	ps.Deck = append(ps.Deck, returned...)
+	g.shuffle(ps.Deck)
+}
+
+// KeepHandAction ends the player's mulligan decisions. Under the London
+// styles Bottom must list exactly as many hand indexes as mulligans cost;
+// those cards go under the deck one at a time in the given order, so the
+// last one listed ends up at the very bottom.
+type KeepHandAction struct {
+	PlayerID string `json:"player_id"`
+	Bottom   []int  `json:"bottom,omitempty"`
+}
+
+func (a KeepHandAction) Validate(g *Game) error {
+	ps, err := g.checkMulligan(a.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	if want := g.mulliganBottomCount(ps.Mulligans); len(a.Bottom) != want {
+		return fmt.Errorf("%w: got %d, want %d", ErrBottomCount, len(a.Bottom), want)
+	}
+
+	return checkHandIndexes(ps, a.Bottom)
+}
+
+func (a KeepHandAction) Apply(g *Game) error {
+	ps := g.playerByID(a.PlayerID)
+
+	if len(a.Bottom) > 0 {
+		bottom := make([]CardInstance, 0, len(a.Bottom))
+		for _, idx := range a.Bottom {
+			bottom = append(bottom, ps.Hand[idx])
+		}
+
+		// Remove from the highest index down so earlier indexes stay valid
+		idxs := slices.Clone(a.Bottom)
+		slices.Sort(idxs)
+		for i := len(idxs) - 1; i >= 0; i-- {
+			ps.Hand = slices.Delete(ps.Hand, idxs[i], idxs[i]+1)
+		}
+
+		// The deck is drawn from the end, so the bottom is the front
+		slices.Reverse(bottom)
+		ps.Deck = append(bottom, ps.Deck...)
+	}
+
+	ps.HandKept = true
+	g.log("mulligan", ps.PlayerID, "%s keeps %d cards", ps.PlayerID, len(ps.Hand))
+	g.endMulligan()
+	return nil
+}
+
+func (g *Game) endMulligan() {
+	if !g.inMulligan() {
+		g.log("mulligan", "", "mulligan phase over")
+	}
+}
+
+func (g *Game) MulliganHand(playerID string, handIdxs []int) error {
+	return g.Submit(MulliganAction{PlayerID: playerID, HandIdxs: handIdxs})
+}
+
+func (g *Game) KeepHand(playerID string, bottom []int) error {
+	return g.Submit(KeepHandAction{PlayerID: playerID, Bottom: bottom})
+}
+
+// mulliganActions lists the decisions open to the player during the
+// mulligan phase. Partial redraws are not enumerated since every subset of
+// the hand would be its own action.
+func (g *Game) mulliganActions(playerID string) []Action {
+	ps := g.playerByID(playerID)
+	if ps == nil || ps.HandKept {
+		return nil
+	}
+
+	var actions []Action
+	if g.Options.Mulligan != MulliganPartial {
+		if a := (MulliganAction{PlayerID: playerID}); a.Validate(g) == nil {
+			actions = append(actions, a)
+		}
+	}
+
+	for _, bottom := range handSubsets(len(ps.Hand), g.mulliganBottomCount(ps.Mulligans)) {
+		actions = append(actions, KeepHandAction{PlayerID: playerID, Bottom: bottom})
+	}
+
+	return actions
+}
+
+// handSubsets returns every k-element subset of 0..n-1 in ascending order.
+// The empty subset is returned as nil.
+func handSubsets(n, k int) [][]int {
+	if k == 0 {
+		return [][]int{nil}
+	}
+
+	var out [][]int
+	var walk func(start int, picked []int)
+	walk = func(start int, picked []int) {
+		if len(picked) == k {
+			out = append(out, slices.Clone(picked))
+			return
+		}
+		for i := start; i < n; i++ {
+			walk(i+1, append(picked, i))
+		}
+	}
+	walk(0, nil)
+	return out
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulligan_BlocksGameUntilBothKeep(t *testing.T) {
+	g := newMulliganGame(t, MulliganLondon)
+
+	assert.ErrorIs(t, g.StartTurn(), ErrMulliganPending)
+	assert.ErrorIs(t, g.PlayCard("p1", 0, nil), ErrMulliganPending)
+
+	// Either player may decide first
+	require.NoError(t, g.KeepHand("p2", nil))
+	assert.ErrorIs(t, g.StartTurn(), ErrMulliganPending)
+	assert.ErrorIs(t, g.KeepHand("p2", nil), ErrHandKept)
+
+	require.NoError(t, g.KeepHand("p1", nil))
+	require.NoError(t, g.StartTurn())
+
+	assert.ErrorIs(t, g.KeepHand("p1", nil), ErrNotInMulligan)
+}
+
+func TestMulligan_NoneSkipsPhase(t *testing.T) {
+	g := newMulliganGame(t, MulliganNone)
+	assert.ErrorIs(t, g.KeepHand("p1", nil), ErrNotInMulligan)
+	require.NoError(t, g.StartTurn())
+}
+
+func TestMulligan_London(t *testing.T) {
+	g := newMulliganGame(t, MulliganLondon)
+	p1 := g.Players[0]
+	before := collectIDs(p1.Hand)
+	total := len(p1.Hand) + len(p1.Deck)
+
+	require.NoError(t, g.MulliganHand("p1", nil))
+	assert.Equal(t, 1, p1.Mulligans)
+	assert.Len(t, p1.Hand, 4, "London redraws a full hand")
+	assert.NotEqual(t, before, collectIDs(p1.Hand))
+	assert.Equal(t, total, len(p1.Hand)+len(p1.Deck))
+
+	require.NoError(t, g.MulliganHand("p1", nil))
+
+	// Keeping after two mulligans puts two cards on the bottom
+	assert.ErrorIs(t, g.KeepHand("p1", nil), ErrBottomCount)
+	assert.ErrorIs(t, g.KeepHand("p1", []int{0, 0}), ErrInvalidHandIndex)
+	assert.ErrorIs(t, g.KeepHand("p1", []int{0, 9}), ErrInvalidHandIndex)
+
+	first, third := p1.Hand[0], p1.Hand[2]
+	require.NoError(t, g.KeepHand("p1", []int{2, 0}))
+	assert.Len(t, p1.Hand, 2)
+	assert.Equal(t, total, len(p1.Hand)+len(p1.Deck))
+
+	// Cards go under one at a time, so the last listed ends up deepest
+	assert.Equal(t, first.InstanceID, p1.Deck[0].InstanceID)
+	assert.Equal(t, third.InstanceID, p1.Deck[1].InstanceID)
+}
+
+func TestMulligan_LondonLimit(t *testing.T) {
+	g := newMulliganGame(t, MulliganLondon)
+
+	for range 3 {
+		require.NoError(t, g.MulliganHand("p1", nil))
+	}
+	assert.ErrorIs(t, g.MulliganHand("p1", nil), ErrMulliganLimit)
+	assert.ErrorIs(t, g.MulliganHand("p1", []int{0}), ErrInvalidHandIndex)
+}
+
+func TestMulligan_FreeFirst(t *testing.T) {
+	g := newMulliganGame(t, MulliganFreeFirst)
+	p1 := g.Players[0]
+
+	require.NoError(t, g.MulliganHand("p1", nil))
+	require.NoError(t, g.KeepHand("p1", nil), "first mulligan is free")
+	assert.Len(t, p1.Hand, 4)
+
+	require.NoError(t, g.MulliganHand("p2", nil))
+	require.NoError(t, g.MulliganHand("p2", nil))
+	assert.ErrorIs(t, g.KeepHand("p2", nil), ErrBottomCount)
+	require.NoError(t, g.KeepHand("p2", []int{1}))
+	assert.Len(t, g.Players[1].Hand, 3)
+}
+
+func TestMulligan_Partial(t *testing.T) {
+	g := newMulliganGame(t, MulliganPartial)
+	p1 := g.Players[0]
+	before := collectIDs(p1.Hand)
+	total := len(p1.Hand) + len(p1.Deck)
+
+	require.NoError(t, g.MulliganHand("p1", []int{0, 2}))
+	assert.True(t, p1.HandKept, "a partial mulligan is final")
+	assert.Len(t, p1.Hand, 4)
+	assert.Equal(t, total, len(p1.Hand)+len(p1.Deck))
+
+	after := collectIDs(p1.Hand)
+	assert.Equal(t, before[1], after[1])
+	assert.Equal(t, before[3], after[3])
+	assert.NotContains(t, after, before[0], "replaced cards can't be redrawn")
+	assert.NotContains(t, after, before[2])
+
+	assert.ErrorIs(t, g.MulliganHand("p2", []int{7}), ErrInvalidHandIndex)
+	require.NoError(t, g.KeepHand("p2", nil))
+	require.NoError(t, g.StartTurn())
+}
+
+func TestMulligan_DeterministicAndReplayable(t *testing.T) {
+	play := func() *Game {
+		g := newMulliganGame(t, MulliganLondon)
+		require.NoError(t, g.MulliganHand("p1", nil))
+		require.NoError(t, g.MulliganHand("p2", nil))
+		require.NoError(t, g.KeepHand("p2", []int{0}))
+		require.NoError(t, g.KeepHand("p1", []int{3}))
+		require.NoError(t, g.StartTurn())
+		return g
+	}
+
+	a, b := play(), play()
+	assert.Equal(t, collectIDs(a.Players[0].Hand), collectIDs(b.Players[0].Hand))
+	assert.Equal(t, collectIDs(a.Players[1].Deck), collectIDs(b.Players[1].Deck))
+
+	replayed, err := a.Replay().Run()
+	require.NoError(t, err)
+	assert.Equal(t, collectIDs(a.Players[0].Hand), collectIDs(replayed.Players[0].Hand))
+}
+
+func TestMulligan_LegalActions(t *testing.T) {
+	g := newMulliganGame(t, MulliganLondon)
+
+	actions := g.LegalActions("p1")
+	require.Len(t, actions, 2)
+	assert.Equal(t, MulliganAction{PlayerID: "p1"}, actions[0])
+	assert.Equal(t, KeepHandAction{PlayerID: "p1"}, actions[1])
+
+	require.NoError(t, g.MulliganHand("p1", nil))
+	actions = g.LegalActions("p1")
+	assert.Len(t, actions, 1+4, "mulligan again or bottom any one of four cards")
+	for _, a := range actions {
+		assert.NoError(t, a.Validate(g))
+	}
+
+	require.NoError(t, g.KeepHand("p1", []int{0}))
+	assert.Empty(t, g.LegalActions("p1"), "waiting for the opponent")
+}
+
+func TestMulligan_Snapshot(t *testing.T) {
+	g := newMulliganGame(t, MulliganLondon)
+	require.NoError(t, g.MulliganHand("p1", nil))
+	require.NoError(t, g.KeepHand("p2", nil))
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := RestoreSnapshot(data, append(makeDeck(20), makeDeck(20)...))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, restored.Players[0].Mulligans)
+	assert.True(t, restored.Players[1].HandKept)
+	assert.ErrorIs(t, restored.StartTurn(), ErrMulliganPending)
+	assert.ErrorIs(t, restored.KeepHand("p1", nil), ErrBottomCount)
+}
@@ -15,25 +15,61 @@ var (
 	ErrInvalidTarget    = errors.New("invalid target")
 	ErrBoardFull        = errors.New("board is full")
 	ErrPlayerNotFound   = errors.New("player not found")
+	ErrTargetCount      = errors.New("wrong number of targets")
+	ErrSpellNoEffects   = errors.New("spell has no effects")
 )
 
 // EffectContext holds all the information any effect function might need
 type EffectContext struct {
 	Game       *Game
 	Caster     *PlayerState
+	Source     *CardInstance // card the effect comes from, for keyword rules
 	Target     *TargetRef
 	Amount     int
 	BuffAttack int
 	BuffHealth int
+	Choices    []cards.Effect    // options of a choose_one effect
+	Into       *cards.CardDef    // what a transform turns its target into
+	CardID     string            // token a summon creates
+	Random     bool              // discard picks cards at random
+	Filter     *cards.CardFilter // cards a tutor can find
+
+	// Set while the effect hits several targets at once: deaths and
+	// on_damaged triggers wait until every target has been hit.
+	simultaneous bool
+	damaged      []CardInstance
 }
 
-// effectResolver is our function map - maps effect kinds to their implementation
-var effectResolver = map[cards.EffectKind]func(*EffectContext) error{
-	cards.EffectDamage:        applyDamage,
-	cards.EffectHeal:          applyHealing,
-	cards.EffectDrawCards:     applyDrawCards,
-	cards.EffectBuffStatsPerm: applyBuffStatsPerm,
-	cards.EffectBuffStatsTemp: applyBuffStatsTemp,
+// effectResolver is our function map - maps effect kinds to their implementation.
+// It is filled in init because resolvers can fire triggers, which look up
+// effectResolver again (a package-level initializer would be a cycle).
+var effectResolver map[cards.EffectKind]func(*EffectContext) error
+
+func init() {
+	effectResolver = map[cards.EffectKind]func(*EffectContext) error{
+		cards.EffectDamage:          applyDamage,
+		cards.EffectHeal:            applyHealing,
+		cards.EffectDrawCards:       applyDrawCards,
+		cards.EffectBuffStatsPerm:   applyBuffStatsPerm,
+		cards.EffectBuffStatsTemp:   applyBuffStatsTemp,
+		cards.EffectCounter:         applyCounter,
+		cards.EffectChooseOne:       applyChooseOne,
+		cards.EffectDiscover:        applyDiscover,
+		cards.EffectDestroy:         applyDestroy,
+		cards.EffectReturnToHand:    applyReturnToHand,
+		cards.EffectSilence:         applySilence,
+		cards.EffectFreeze:          applyFreeze,
+		cards.EffectTransform:       applyTransform,
+		cards.EffectSummon:          applySummon,
+		cards.EffectResurrect:       applyResurrect,
+		cards.EffectRecycle:         applyRecycle,
+		cards.EffectShuffleIntoDeck: applyShuffleIntoDeck,
+		cards.EffectMill:            applyMill,
+		cards.EffectDiscard:         applyDiscard,
+		cards.EffectScry:            applyScry,
+		cards.EffectTutor:           applyTutor,
+		cards.EffectShuffle:         applyShuffle,
+	}
 }
 
 func (g *Game) autoPopulateTarget(effect cards.Effect, providedTarget *TargetRef, caster *PlayerState) *TargetRef {
@@ -41,259 +77,389 @@ func (g *Game) autoPopulateTarget(effect cards.Effect, providedTarget *TargetRef
 	case cards.TargetSelfPlayer:
 		return &TargetRef{PlayerID: caster.PlayerID}
 	case cards.TargetEnemyPlayer:
-		return &TargetRef{PlayerID: g.Opponent().PlayerID}
+		return &TargetRef{PlayerID: g.opponentOf(caster).PlayerID}
 	default:
+		if engineTargeted(effect.Target) {
+			return &TargetRef{} // filled in by runEffects
+		}
 		return providedTarget
 	}
 }
 
-func (g *Game) PlayCard(playerID string, handIdx int, targets []*TargetRef) error {
-	if g.CurrentPlayer().PlayerID != playerID {
-		return ErrNotYourTurn
-	}
+// PlayCardAction plays the card at HandIdx from the player's hand. Targets
+// holds one entry per card effect; player targets may be left nil and are
+// filled in automatically.
+type PlayCardAction struct {
+	PlayerID string       `json:"player_id"`
+	HandIdx  int          `json:"hand_idx"`
+	Targets  []*TargetRef `json:"targets,omitempty"`
+}
 
-	activePlayer := g.CurrentPlayer()
+func (a PlayCardAction) Validate(g *Game) error {
+	caster := g.playerByID(a.PlayerID)
+	if caster == nil {
+		return fmt.Errorf("%w: %s", ErrPlayerNotFound, a.PlayerID)
+	}
 
-	if handIdx < 0 || handIdx >= len(activePlayer.Hand) {
+	if a.HandIdx < 0 || a.HandIdx >= len(caster.Hand) {
 		return ErrInvalidHandIndex
 	}
 
-	card := activePlayer.Hand[handIdx]
+	card := caster.Hand[a.HandIdx]
 
-	if activePlayer.CurrentEnergy < card.Def.Cost {
-		return ErrNotEnoughEnergy
+	if err := g.checkPlayable(caster, card); err != nil {
+		return err
 	}
 
 	if card.Def.Type == cards.TypeSpell {
-		if len(card.Def.Effects) <= 0 {
-			return fmt.Errorf("spells must have at least 1 effect, got %d", len(card.Def.Effects))
-		}
-		if len(targets) != len(card.Def.Effects) {
-			return fmt.Errorf("expected %d targets, got %d", len(card.Def.Effects), len(targets))
+		if len(a.Targets) != len(card.Def.Effects) {
+			return fmt.Errorf("%w: expected %d, got %d", ErrTargetCount, len(card.Def.Effects), len(a.Targets))
 		}
 
 		for i, effect := range card.Def.Effects {
-			if effect.Target == cards.TargetSelfPlayer || effect.Target == cards.TargetEnemyPlayer {
+			if engineTargeted(effect.Target) {
 				continue
 			}
 
-			if err := g.validateTarget(effect.Target, targets[i], activePlayer); err != nil {
+			if err := g.validateEffectTarget(effect, a.Targets[i], caster); err != nil {
 				return fmt.Errorf("effect %d validation failed: %w", i, err)
 			}
 		}
 	}
 
-	activePlayer.CurrentEnergy -= card.Def.Cost
+	return nil
+}
+
+// checkPlayable runs every PlayCard check that doesn't depend on targets.
+func (g *Game) checkPlayable(caster *PlayerState, card CardInstance) error {
+	// Nothing is played before the active player has drawn and readied
+	if !g.TurnStarted {
+		return ErrTurnNotStarted
+	}
+
+	if err := g.checkPriority(caster, card); err != nil {
+		return err
+	}
+
+	if caster.CurrentEnergy < card.Def.Cost {
+		return ErrNotEnoughEnergy
+	}
+
+	if card.Def.Type == cards.TypeSpell && len(card.Def.Effects) <= 0 {
+		return fmt.Errorf("%w: %s", ErrSpellNoEffects, card.Def.ID)
+	}
 
 	if card.Def.Type == cards.TypeCreature {
-		activePlayer.Board = append(activePlayer.Board, card)
+		if g.Options.MaxBoardSize > 0 && len(caster.Board) >= g.Options.MaxBoardSize {
+			return ErrBoardFull
+		}
 	}
 
-	if card.Def.Type == cards.TypeSpell {
-		activePlayer.Graveyard = append(activePlayer.Graveyard, card)
+	return nil
+}
+
+func (a PlayCardAction) Apply(g *Game) error {
+	caster := g.playerByID(a.PlayerID)
+	card := caster.Hand[a.HandIdx]
+
+	caster.CurrentEnergy -= card.Def.Cost
+
+	if card.Def.Type == cards.TypeCreature {
+		card.SummoningSick = !card.Def.HasKeyword(cards.KeywordHaste)
+		card.DivineShield = card.Def.HasKeyword(cards.KeywordDivineShield)
+		caster.Board = append(caster.Board, card)
 	}
 
-	if len(activePlayer.Hand) > handIdx+1 {
-		activePlayer.Hand = append(activePlayer.Hand[:handIdx], activePlayer.Hand[handIdx+1:]...)
+	if len(caster.Hand) > a.HandIdx+1 {
+		caster.Hand = append(caster.Hand[:a.HandIdx], caster.Hand[a.HandIdx+1:]...)
 	} else {
-		activePlayer.Hand = activePlayer.Hand[:handIdx]
+		caster.Hand = caster.Hand[:a.HandIdx]
 	}
 
+	// Spells wait on the stack; the opponent gets a chance to respond
 	if card.Def.Type == cards.TypeSpell {
-		for i, effect := range card.Def.Effects {
-			applySpell := effectResolver[effect.Kind]
-			actualTarget := g.autoPopulateTarget(effect, targets[i], activePlayer)
-			effectContext := EffectContext{Game: g, Caster: activePlayer, Target: actualTarget, Amount: effect.Amount, BuffAttack: effect.BuffAttack, BuffHealth: effect.BuffHealth}
-			applySpell(&effectContext)
-		}
+		g.pushStack(caster, card, a.Targets)
 	}
 
-	// Step 7 - Check for state-based effects (creature death, game end, etc.)
-	gameEnded, endMessage := g.checkStateBasedEffects()
-	if gameEnded {
-		g.GameEnded = true
-		g.Winner = endMessage
-		g.log("state_based_effects", "", "Game ended: %s", endMessage)
-		return nil // PlayCard succeeds even if game ends
+	g.fireTrigger(card, cards.TriggerOnPlay)
+
+	// Without priority passing both players implicitly pass straight away
+	if !g.Options.PriorityPassing {
+		g.resolveStack()
 	}
 
+	// Step 7 - Check for state-based effects (creature death, game end, etc.)
+	// PlayCard succeeds even if game ends
+	g.resolveStateBasedEffects()
+
 	return nil
 }
 
+func (g *Game) PlayCard(playerID string, handIdx int, targets []*TargetRef) error {
+	return g.Submit(PlayCardAction{PlayerID: playerID, HandIdx: handIdx, Targets: targets})
+}
+
+// CanPlayCard reports whether PlayCard with the same arguments would succeed.
+func (g *Game) CanPlayCard(playerID string, handIdx int, targets []*TargetRef) error {
+	return PlayCardAction{PlayerID: playerID, HandIdx: handIdx, Targets: targets}.Validate(g)
+}
+
 // Apply damage to player or creature
 func applyDamage(ctx *EffectContext) error {
 	// Try player damage first
-	if player := ctx.Game.getTargetPlayer(ctx.Target); player != nil {
+	if player, err := ctx.Game.getTargetPlayer(ctx.Target); err == nil {
 		player.Life -= ctx.Amount
 		ctx.Game.log("damage", ctx.Caster.PlayerID, "%d damage dealt to %s", ctx.Amount, player.PlayerID)
+		ctx.Game.applyLifesteal(ctx.Source, ctx.Amount)
 		return nil
 	}
 
 	// Try creature damage
-	if creature := ctx.Game.getTargetCreature(ctx.Target); creature != nil {
-		creature.CurrentDamage += ctx.Amount
-		creature.CurrentHealth = creature.Def.Health + creature.PermHealthBuff + creature.TempHealthBuff - creature.CurrentDamage
-		ctx.Game.log("damage", ctx.Caster.PlayerID, "%d damage dealt to %s", ctx.Amount, creature.Def.Name)
-
-		// Check for creature death
-		if creature.CurrentHealth <= 0 {
-			return ctx.Game.moveToGraveyard(creature, fmt.Sprintf("destroyed by %d damage", ctx.Amount))
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyDamage: %w", err)
+	}
+	dealt := ctx.Game.damageCreature(ctx.Source, creature, ctx.Amount)
+	ctx.Game.log("damage", ctx.Caster.PlayerID, "%d damage dealt to %s", dealt, creature.Def.Name)
+	ctx.Game.applyLifesteal(ctx.Source, dealt)
+
+	// Keep a copy for the trigger, the pointer is invalid once the creature moves
+	damaged := *creature
+	if ctx.simultaneous {
+		if dealt > 0 {
+			ctx.damaged = append(ctx.damaged, damaged)
 		}
 		return nil
 	}
 
-	// Should never happen if validation worked
-	return fmt.Errorf("applyDamage: no valid target found")
+	// Check for creature death
+	if creature.CurrentHealth <= 0 || creature.Destroyed {
+		err = ctx.Game.moveToGraveyard(creature, fmt.Sprintf("destroyed by %d damage", ctx.Amount))
+	}
+	if dealt > 0 {
+		ctx.Game.fireTrigger(damaged, cards.TriggerOnDamaged)
+	}
+	return err
+}
+
+// damageCreature deals damage from source to creature, applying the divine
+// shield and poisonous keywords. It returns the damage actually dealt. Death is
+// left to the caller or to state-based effects.
+func (g *Game) damageCreature(source, creature *CardInstance, amount int) int {
+	if amount <= 0 {
+		return 0
+	}
+
+	if creature.DivineShield {
+		creature.DivineShield = false
+		g.log("divine_shield", creature.Controller, "%s (%s) divine shield absorbed %d damage", creature.Def.Name, creature.InstanceID, amount)
+		return 0
+	}
+
+	creature.CurrentDamage += amount
+	computeStats(creature)
+
+	if source != nil && source.Def.HasKeyword(cards.KeywordPoisonous) {
+		creature.Destroyed = true
+		g.log("poisonous", source.Controller, "%s (%s) poisoned by %s", creature.Def.Name, creature.InstanceID, source.Def.Name)
+	}
+
+	return amount
+}
+
+// applyLifesteal heals the source's controller for the damage it dealt.
+func (g *Game) applyLifesteal(source *CardInstance, dealt int) {
+	if source == nil || dealt <= 0 || !source.Def.HasKeyword(cards.KeywordLifesteal) {
+		return
+	}
+	controller := g.playerByID(source.Controller)
+	if controller == nil {
+		return
+	}
+	controller.Life += dealt
+	if g.inHiddenZone(source) {
+		g.logPrivate("lifesteal", controller.PlayerID, source.Def.Name, "a hidden card healed %s for %d", controller.PlayerID, dealt)
+		return
+	}
+	g.log("lifesteal", controller.PlayerID, "%s healed %s for %d", source.Def.Name, controller.PlayerID, dealt)
 }
 
 func applyHealing(ctx *EffectContext) error {
-	if player := ctx.Game.getTargetPlayer(ctx.Target); player != nil {
+	if player, err := ctx.Game.getTargetPlayer(ctx.Target); err == nil {
 		player.Life += ctx.Amount
 		ctx.Game.log("healing", ctx.Caster.PlayerID, "%d healing applied to %s", ctx.Amount, player.PlayerID)
 		return nil
 	}
-	return fmt.Errorf("applyHealing: %w", ErrPlayerNotFound)
+
+	// Creatures only heal the damage they have taken
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyHealing: %w", err)
+	}
+	healed := min(ctx.Amount, creature.CurrentDamage)
+	creature.CurrentDamage -= healed
+	computeStats(creature)
+	ctx.Game.log("healing", ctx.Caster.PlayerID, "%d healing applied to %s", healed, creature.Def.Name)
+	return nil
 }
 
 func applyDrawCards(ctx *EffectContext) error {
-	if player := ctx.Game.getTargetPlayer(ctx.Target); player != nil {
-		drawn := ctx.Game.Draw(player, ctx.Amount)
-		ctx.Game.log("draw_cards", ctx.Caster.PlayerID, "%s drew %d cards", player.PlayerID, drawn)
-		return nil
+	player, err := ctx.Game.getTargetPlayer(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyDrawCards: %w", err)
 	}
-	return fmt.Errorf("applyDrawCards: %w", ErrPlayerNotFound)
+	drawn := ctx.Game.Draw(player, ctx.Amount)
+	ctx.Game.log("draw_cards", ctx.Caster.PlayerID, "%s drew %d cards", player.PlayerID, drawn)
+	return nil
 }
 
 func applyBuffStatsPerm(ctx *EffectContext) error {
-	if creature := ctx.Game.getTargetCreature(ctx.Target); creature != nil {
-		creature.PermAttackBuff += ctx.BuffAttack
-		creature.PermHealthBuff += ctx.BuffHealth
-		creature.CurrentAttack = creature.Def.Attack + creature.PermAttackBuff + creature.TempAttackBuff
-		creature.CurrentHealth = creature.Def.Health + creature.PermHealthBuff + creature.TempHealthBuff
-		ctx.Game.log("buff_creature_perm", ctx.Caster.PlayerID, "+%d/+%d permanent buff applied to %s", ctx.BuffAttack, ctx.BuffHealth, creature.InstanceID)
-		return nil
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyBuffStatsPerm: %w", err)
 	}
-	return fmt.Errorf("applyBuffStatsPerm: %w", ErrInvalidTarget)
+	creature.PermAttackBuff += ctx.BuffAttack
+	creature.PermHealthBuff += ctx.BuffHealth
+	computeStats(creature)
+	ctx.Game.log("buff_creature_perm", ctx.Caster.PlayerID, "+%d/+%d permanent buff applied to %s", ctx.BuffAttack, ctx.BuffHealth, creature.InstanceID)
+	return nil
 }
 
 func applyBuffStatsTemp(ctx *EffectContext) error {
-	if creature := ctx.Game.getTargetCreature(ctx.Target); creature != nil {
-		creature.TempAttackBuff += ctx.BuffAttack
-		creature.TempHealthBuff += ctx.BuffHealth
-		creature.CurrentAttack = creature.Def.Attack + creature.PermAttackBuff + creature.TempAttackBuff
-		creature.CurrentHealth = creature.Def.Health + creature.PermHealthBuff + creature.TempHealthBuff
-		ctx.Game.log("buff_creature_temp", ctx.Caster.PlayerID, "+%d/+%d temporary buff applied to %s", ctx.BuffAttack, ctx.BuffHealth, creature.InstanceID)
-		return nil
+	creature, err := ctx.Game.getTargetCreature(ctx.Target)
+	if err != nil {
+		return fmt.Errorf("applyBuffStatsTemp: %w", err)
 	}
-	return fmt.Errorf("applyBuffStatsTemp: %w", ErrInvalidTarget)
+	creature.TempAttackBuff += ctx.BuffAttack
+	creature.TempHealthBuff += ctx.BuffHealth
+	computeStats(creature)
+	ctx.Game.log("buff_creature_temp", ctx.Caster.PlayerID, "+%d/+%d temporary buff applied to %s", ctx.BuffAttack, ctx.BuffHealth, creature.InstanceID)
+	return nil
 }
 
 // Helper functions for effect targeting - assume validation already passed
-func (g *Game) getTargetPlayer(targetRef *TargetRef) *PlayerState {
+// getTargetPlayer returns the player targetRef names. A missing target is
+// ErrMissingTarget and one that names no player is ErrPlayerNotFound.
+func (g *Game) getTargetPlayer(targetRef *TargetRef) (*PlayerState, error) {
+	if targetRef == nil {
+		return nil, ErrMissingTarget
+	}
 	if targetRef.PlayerID == "" {
-		return nil
+		return nil, ErrPlayerNotFound
 	}
 	for _, player := range g.Players {
 		if player.PlayerID == targetRef.PlayerID {
-			return player
+			return player, nil
 		}
 	}
 	// Should never happen if validation worked
 	g.log("error", "", "getTargetPlayer failed to find player %s", targetRef.PlayerID)
-	return nil
+	return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, targetRef.PlayerID)
 }
 
-func (g *Game) getTargetCreature(targetRef *TargetRef) *CardInstance {
+// getTargetCreature returns the creature on a board that targetRef names. A
+// missing target is ErrMissingTarget and one that names no creature in play
+// is ErrInvalidTarget.
+func (g *Game) getTargetCreature(targetRef *TargetRef) (*CardInstance, error) {
+	if targetRef == nil {
+		return nil, ErrMissingTarget
+	}
 	if targetRef.InstanceID == nil {
-		return nil
+		return nil, ErrInvalidTarget
 	}
 	for _, player := range g.Players {
 		// Board should be the only place where creatures can take damage
 		for i := range player.Board {
 			if player.Board[i].InstanceID == *targetRef.InstanceID {
-				return &player.Board[i] // Direct reference to slice element
+				return &player.Board[i], nil // Direct reference to slice element
 			}
 		}
 	}
 	// Should never happen if validation worked
 	g.log("error", "", "getTargetCreature failed to find creature %s", *targetRef.InstanceID)
-	return nil
+	return nil, fmt.Errorf("%w: %s", ErrInvalidTarget, *targetRef.InstanceID)
 }
 
 func (g *Game) checkStateBasedEffects() (bool, string) {
-	p1Alive := g.Players[0].Life > 0
-	p2Alive := g.Players[1].Life > 0
+	// Check for creature deaths first, since death triggers may still change
+	// life totals. Dead creatures are collected before moving any of them so
+	// that triggers changing the boards can't disturb the scan.
+	for {
+		// Deaths can end auras, which may kill more creatures
+		g.recomputeStats()
+
+		type death struct {
+			playerID string
+			id       InstanceID
+		}
+		var dead []death
+		for i := range g.Players {
+			for j := range g.Players[i].Board {
+				creature := &g.Players[i].Board[j]
+				if creature.Destroyed || creature.CurrentHealth <= 0 {
+					dead = append(dead, death{playerID: g.Players[i].PlayerID, id: creature.InstanceID})
+				}
+			}
+		}
+		if len(dead) == 0 {
+			break
+		}
+
+		for _, d := range dead {
+			creature, ok := g.findCardInstance(d.id)
+			if !ok {
+				continue
+			}
+			if creature.Destroyed {
+				g.log("creature_death", d.playerID, "%s (%s) was destroyed", creature.Def.Name, creature.InstanceID)
+				g.moveToGraveyard(creature, "destroyed")
+			} else {
+				g.log("creature_death", d.playerID, "%s (%s) died with %d health", creature.Def.Name, creature.InstanceID, creature.CurrentHealth)
+				g.moveToGraveyard(creature, "life reached 0")
+			}
+		}
+	}
 
-	// Check for game-ending conditions first
+	p1Alive := g.Players[0].Life > 0 && !g.Players[0].DeckedOut
+	p2Alive := g.Players[1].Life > 0 && !g.Players[1].DeckedOut
+
+	// Check for game-ending conditions
 	switch {
 	case !p1Alive && !p2Alive:
 		g.log("game_end", "", "Both players died simultaneously - game is a draw!")
 		return true, "Draw!"
 	case p1Alive && !p2Alive:
 		winner := g.Players[0].Name
-		g.log("game_end", g.Players[0].PlayerID, "%s wins! %s died with %d life", winner, g.Players[1].Name, g.Players[1].Life)
+		g.log("game_end", g.Players[0].PlayerID, "%s wins! %s %s", winner, g.Players[1].Name, defeatReason(g.Players[1]))
 		return true, fmt.Sprintf("%s a winner is you!", winner)
 	case !p1Alive && p2Alive:
 		winner := g.Players[1].Name
-		g.log("game_end", g.Players[1].PlayerID, "%s wins! %s died with %d life", winner, g.Players[0].Name, g.Players[0].Life)
+		g.log("game_end", g.Players[1].PlayerID, "%s wins! %s %s", winner, g.Players[0].Name, defeatReason(g.Players[0]))
 		return true, fmt.Sprintf("%s a winner is you!", winner)
 	}
 
-	// Check for creature deaths (iterate backwards to handle removal safely)
-	for i := range g.Players {
-		for j := len(g.Players[i].Board) - 1; j >= 0; j-- {
-			creature := &g.Players[i].Board[j]
-			if creature.CurrentHealth <= 0 {
-				g.log("creature_death", g.Players[i].PlayerID, "%s (%s) died with %d health", creature.Def.Name, creature.InstanceID, creature.CurrentHealth)
-				g.moveToGraveyard(creature, "life reached 0")
-			}
-		}
-	}
-
 	return false, ""
 }
 
-func (g *Game) CanPlayCard(playerID string, handIdx int, targets []*TargetRef) error {
-	if g.CurrentPlayer().PlayerID != playerID {
-		return ErrNotYourTurn
-	}
-
-	activePlayer := g.CurrentPlayer()
-
-	if handIdx < 0 || handIdx >= len(activePlayer.Hand) {
-		return ErrInvalidHandIndex
-	}
-
-	card := activePlayer.Hand[handIdx]
-
-	if activePlayer.CurrentEnergy < card.Def.Cost {
-		return ErrNotEnoughEnergy
+func defeatReason(ps *PlayerState) string {
+	if ps.Life <= 0 {
+		return fmt.Sprintf("died with %d life", ps.Life)
 	}
+	return "decked out"
+}
 
-	if card.Def.Type == cards.TypeSpell {
-		if len(card.Def.Effects) <= 0 {
-			return fmt.Errorf("spells must have at least 1 effect, got %d", len(card.Def.Effects))
-		}
-		if len(targets) != len(card.Def.Effects) {
-			return fmt.Errorf("expected %d targets, got %d", len(card.Def.Effects), len(targets))
-		}
-
-		for i, effect := range card.Def.Effects {
-			if effect.Target == cards.TargetSelfPlayer || effect.Target == cards.TargetEnemyPlayer {
-				continue
-			}
-
-			if err := g.validateTarget(effect.Target, targets[i], activePlayer); err != nil {
-				return fmt.Errorf("effect %d validation failed: %w", i, err)
-			}
-		}
+// resolveStateBasedEffects runs checkStateBasedEffects and records the
+// outcome on the game if it ended. Once the game is over the outcome is
+// final, so later checks do nothing.
+func (g *Game) resolveStateBasedEffects() {
+	if g.GameEnded {
+		return
 	}
 
-	if card.Def.Type == cards.TypeCreature {
-		if g.Options.MaxBoardSize > 0 && len(activePlayer.Board) >= g.Options.MaxBoardSize {
-			return ErrBoardFull
-		}
+	gameEnded, endMessage := g.checkStateBasedEffects()
+	if gameEnded {
+		g.GameEnded = true
+		g.Winner = endMessage
+		g.log("state_based_effects", "", "Game ended: %s", endMessage)
 	}
-
-	return nil
 }
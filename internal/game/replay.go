@@ -0,0 +1,142 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// ReplayVersion is bumped whenever the replay layout or the log the engine
+// writes changes, since replays are checked against their recorded log.
+const ReplayVersion = 1
+
+var (
+	ErrReplayVersion  = errors.New("unsupported replay version")
+	ErrReplayNoSeed   = errors.New("replay needs a fixed seed")
+	ErrReplayDiverged = errors.New("replay diverged from recorded log")
+)
+
+// Replay is everything needed to rebuild a game deterministically: how it
+// was created and the ordered actions submitted to it. Log is the log the
+// original game produced and is used to check the rebuilt game.
+type Replay struct {
+	Version   int
+	PlayerIDs [2]string
+	Options   Options
+	Decks     [2][]cards.CardDef
+	Actions   []Action
+	Log       []Event
+}
+
+type replayJSON struct {
+	Version   int                `json:"version"`
+	PlayerIDs [2]string          `json:"player_ids"`
+	Options   Options            `json:"options"`
+	Decks     [2][]cards.CardDef `json:"decks"`
+	Actions   []json.RawMessage  `json:"actions"`
+	Log       []Event            `json:"log,omitempty"`
+}
+
+// Replay captures the game so far. Options.Seed is always set by NewGame, so
+// the result can be run as-is.
+func (g *Game) Replay() *Replay {
+	return &Replay{
+		Version:   ReplayVersion,
+		PlayerIDs: [2]string{g.Players[0].PlayerID, g.Players[1].PlayerID},
+		Options:   g.Options,
+		Decks:     g.decks,
+		Actions:   append([]Action(nil), g.History...),
+		Log:       append([]Event(nil), g.Log...),
+	}
+}
+
+// Run rebuilds the game by creating it from the recorded options and decks
+// and submitting every action in order. If the replay carries a log, the
+// rebuilt game's log must match it exactly.
+func (r *Replay) Run() (*Game, error) {
+	if r.Version != ReplayVersion {
+		return nil, fmt.Errorf("%w: %d", ErrReplayVersion, r.Version)
+	}
+
+	if r.Options.Seed == 0 {
+		return nil, ErrReplayNoSeed
+	}
+
+	g, err := NewGame(r.PlayerIDs[0], r.PlayerIDs[1], r.Decks[0], r.Decks[1], r.Options)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+
+	for i, a := range r.Actions {
+		if err := g.Submit(a); err != nil {
+			return g, fmt.Errorf("replay action %d (%T): %w", i, a, err)
+		}
+	}
+
+	if r.Log == nil {
+		return g, nil
+	}
+
+	for i := range max(len(r.Log), len(g.Log)) {
+		if i >= len(r.Log) || i >= len(g.Log) {
+			return g, fmt.Errorf("%w: log length %d, want %d", ErrReplayDiverged, len(g.Log), len(r.Log))
+		}
+		if g.Log[i] != r.Log[i] {
+			return g, fmt.Errorf("%w: event %d is %+v, want %+v", ErrReplayDiverged, i, g.Log[i], r.Log[i])
+		}
+	}
+
+	return g, nil
+}
+
+func (r *Replay) MarshalJSON() ([]byte, error) {
+	out := replayJSON{
+		Version:   r.Version,
+		PlayerIDs: r.PlayerIDs,
+		Options:   r.Options,
+		Decks:     r.Decks,
+		Actions:   make([]json.RawMessage, len(r.Actions)),
+		Log:       r.Log,
+	}
+	for i, a := range r.Actions {
+		data, err := MarshalAction(a)
+		if err != nil {
+			return nil, fmt.Errorf("replay action %d: %w", i, err)
+		}
+		out.Actions[i] = data
+	}
+	return json.Marshal(out)
+}
+
+func (r *Replay) UnmarshalJSON(data []byte) error {
+	var in replayJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	// Actions are only decoded once the version says they can be
+	if in.Version != ReplayVersion {
+		return fmt.Errorf("%w: %d", ErrReplayVersion, in.Version)
+	}
+
+	actions := make([]Action, len(in.Actions))
+	for i, raw := range in.Actions {
+		a, err := UnmarshalAction(raw)
+		if err != nil {
+			return fmt.Errorf("replay action %d: %w", i, err)
+		}
+		actions[i] = a
+	}
+
+	*r = Replay{
+		Version:   in.Version,
+		PlayerIDs: in.PlayerIDs,
+		Options:   in.Options,
+		Decks:     in.Decks,
+		Actions:   actions,
+		Log:       in.Log,
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateReplays = flag.Bool("update-replays", false, "regenerate testdata/replays from the scripted games")
+
+// scriptedReplays are the games checked into testdata/replays.
+var scriptedReplays = map[string]func(t *testing.T) *Game{
+	"auto_play_10_turns": func(t *testing.T) *Game {
+		g, err := NewGame("p0", "p1", makeDeck(20), makeDeck(20), Options{StartingHand: 3, Seed: 1001})
+		require.NoError(t, err)
+		autoPlay(t, g, 10)
+		return g
+	},
+	"priority_passing_8_turns": func(t *testing.T) *Game {
+		g, err := NewGame("p0", "p1", makeDeck(16), makeDeck(16), Options{StartingHand: 4, Seed: 1002, PriorityPassing: true})
+		require.NoError(t, err)
+		autoPlay(t, g, 8)
+		return g
+	},
+}
+
+func TestReplay_RunReproducesGame(t *testing.T) {
+	g, err := NewGame("p0", "p1", makeDeck(20), makeDeck(20), Options{StartingHand: 3, Seed: 31337})
+	require.NoError(t, err)
+	autoPlay(t, g, 8)
+	require.NotEmpty(t, g.History)
+
+	data, err := json.Marshal(g.Replay())
+	require.NoError(t, err)
+
+	var replay Replay
+	require.NoError(t, json.Unmarshal(data, &replay))
+
+	rebuilt, err := replay.Run()
+	require.NoError(t, err)
+	assert.Equal(t, g.Log, rebuilt.Log)
+	assert.Equal(t, g.Players, rebuilt.Players)
+	assert.Equal(t, g.History, rebuilt.History)
+}
+
+func TestReplay_RandomSeedIsRecorded(t *testing.T) {
+	g, err := NewGame("p0", "p1", makeDeck(10), makeDeck(10), Options{StartingHand: 3})
+	require.NoError(t, err)
+	autoPlay(t, g, 2)
+
+	replay := g.Replay()
+	assert.NotZero(t, replay.Options.Seed)
+
+	_, err = replay.Run()
+	assert.NoError(t, err)
+}
+
+func TestReplay_DetectsDivergence(t *testing.T) {
+	g, err := NewGame("p0", "p1", makeDeck(10), makeDeck(10), Options{StartingHand: 3, Seed: 5})
+	require.NoError(t, err)
+	autoPlay(t, g, 2)
+
+	replay := g.Replay()
+	replay.Log[len(replay.Log)-1].Msg = "tampered"
+	_, err = replay.Run()
+	assert.ErrorIs(t, err, ErrReplayDiverged)
+
+	replay = g.Replay()
+	replay.Log = replay.Log[:len(replay.Log)-1]
+	_, err = replay.Run()
+	assert.ErrorIs(t, err, ErrReplayDiverged)
+
+	replay = g.Replay()
+	replay.Actions = append(replay.Actions, EndTurnAction{PlayerID: "nobody"})
+	_, err = replay.Run()
+	assert.ErrorIs(t, err, ErrNotYourTurn)
+}
+
+func TestReplay_Regression(t *testing.T) {
+	dir := filepath.Join("testdata", "replays")
+
+	if *updateReplays {
+		for name, script := range scriptedReplays {
+			data, err := json.MarshalIndent(script(t).Replay(), "", "  ")
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644))
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "no replays in %s; run with -update-replays", dir)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			var replay Replay
+			require.NoError(t, json.Unmarshal(data, &replay))
+
+			_, err = replay.Run()
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,498 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// SnapshotVersion is bumped whenever the snapshot layout changes, including
+// new fields that older snapshots would silently restore as zero.
+const SnapshotVersion = 1
+
+var (
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrUnknownCardDef  = errors.New("unknown card definition")
+)
+
+type snapshot struct {
+	Version     int               `json:"version"`
+	ID          string            `json:"id"`
+	Players     [2]playerSnapshot `json:"players"`
+	Active      int               `json:"active"`
+	Turn        int               `json:"turn"`
+	TurnStarted bool              `json:"turn_started,omitempty"`
+	Options     Options           `json:"options"`
+	Log         []Event           `json:"log"`
+	GameEnded   bool              `json:"game_ended"`
+	Winner      string            `json:"winner,omitempty"`
+
+	CombatPhase   CombatPhase               `json:"combat_phase"`
+	AttackingIDs  []InstanceID              `json:"attacking_ids"`
+	AttackTargets map[InstanceID]InstanceID `json:"attack_targets"`
+	BlockingPairs map[InstanceID]InstanceID `json:"blocking_pairs"`
+
+	Stack    []stackItemSnapshot `json:"stack"`
+	Priority int                 `json:"priority"`
+	Passes   int                 `json:"passes"`
+
+	Decisions   []decisionSnapshot `json:"decisions,omitempty"`
+	EndingTurn  bool               `json:"ending_turn,omitempty"`
+	DecisionSeq int                `json:"decision_seq,omitempty"`
+
+	NextInstance int `json:"next_instance,omitempty"`
+
+	RandSeed  int64  `json:"rand_seed"`
+	RandCalls uint64 `json:"rand_calls"`
+
+	History   []json.RawMessage `json:"history,omitempty"`
+	DeckLists [2][]string       `json:"deck_lists"`
+}
+
+type playerSnapshot struct {
+	PlayerID      string         `json:"player_id"`
+	Name          string         `json:"name"`
+	Life          int            `json:"life"`
+	Deck          []cardSnapshot `json:"deck"`
+	Hand          []cardSnapshot `json:"hand"`
+	Board         []cardSnapshot `json:"board"`
+	Graveyard     []cardSnapshot `json:"graveyard"`
+	CurrentEnergy int            `json:"current_energy"`
+	MaxEnergy     int            `json:"max_energy"`
+	Mulligans     int            `json:"mulligans,omitempty"`
+	HandKept      bool           `json:"hand_kept,omitempty"`
+	Fatigue       int            `json:"fatigue,omitempty"`
+	DeckedOut     bool           `json:"decked_out,omitempty"`
+}
+
+// cardSnapshot is a CardInstance with its definition replaced by the ID.
+type cardSnapshot struct {
+	InstanceID       InstanceID `json:"instance_id"`
+	DefID            string     `json:"def_id"`
+	Owner            string     `json:"owner"`
+	Controller       string     `json:"controller"`
+	PermAttackBuff   int        `json:"perm_attack_buff,omitempty"`
+	PermHealthBuff   int        `json:"perm_health_buff,omitempty"`
+	TempAttackBuff   int        `json:"temp_attack_buff,omitempty"`
+	TempHealthBuff   int        `json:"temp_health_buff,omitempty"`
+	AuraAttackBuff   int        `json:"aura_attack_buff,omitempty"`
+	AuraHealthBuff   int        `json:"aura_health_buff,omitempty"`
+	CurrentDamage    int        `json:"current_damage,omitempty"`
+	CurrentAttack    int        `json:"current_attack,omitempty"`
+	CurrentHealth    int        `json:"current_health,omitempty"`
+	SummoningSick    bool       `json:"summoning_sick,omitempty"`
+	Exhausted        bool       `json:"exhausted,omitempty"`
+	AttackedThisTurn bool       `json:"attacked_this_turn,omitempty"`
+	DivineShield     bool       `json:"divine_shield,omitempty"`
+	Destroyed        bool       `json:"destroyed,omitempty"`
+	FrozenUntil      int        `json:"frozen_until,omitempty"`
+	Token            bool       `json:"token,omitempty"`
+}
+
+type stackItemSnapshot struct {
+	Card    cardSnapshot `json:"card"`
+	Caster  string       `json:"caster"`
+	Targets []*TargetRef `json:"targets"`
+}
+
+// decisionSnapshot is a PendingDecision with its source cards stored like
+// every other card.
+type decisionSnapshot struct {
+	PendingDecision
+	SourceCard *cardSnapshot      `json:"source_card,omitempty"`
+	Then       []deferredSnapshot `json:"then,omitempty"`
+}
+
+type deferredSnapshot struct {
+	DeferredEffect
+	SourceCard *cardSnapshot `json:"source_card,omitempty"`
+}
+
+// MarshalSnapshot encodes the full game state as versioned JSON. Card
+// definitions are stored by ID and the RNG by seed and position, so
+// RestoreSnapshot can rebuild an identical game.
+func (g *Game) MarshalSnapshot() ([]byte, error) {
+	ra, ok := g.Rand.(*randAdapter)
+	if !ok {
+		return nil, fmt.Errorf("snapshot: rand source %T cannot be saved", g.Rand)
+	}
+
+	snap := snapshot{
+		Version:       SnapshotVersion,
+		ID:            g.ID,
+		Active:        g.Active,
+		Turn:          g.Turn,
+		TurnStarted:   g.TurnStarted,
+		Options:       g.Options,
+		Log:           g.Log,
+		GameEnded:     g.GameEnded,
+		Winner:        g.Winner,
+		CombatPhase:   g.CombatPhase,
+		AttackingIDs:  g.AttackingIDs,
+		AttackTargets: g.AttackTargets,
+		BlockingPairs: g.BlockingPairs,
+		Priority:      g.Priority,
+		Passes:        g.passes,
+		Decisions:     snapshotDecisions(g.Decisions),
+		EndingTurn:    g.EndingTurn,
+		DecisionSeq:   g.decisionSeq,
+		NextInstance:  g.nextInstance,
+		RandSeed:      ra.src.seed,
+		RandCalls:     ra.src.calls,
+	}
+
+	for i, p := range g.Players {
+		snap.Players[i] = playerSnapshot{
+			PlayerID:      p.PlayerID,
+			Name:          p.Name,
+			Life:          p.Life,
+			Deck:          snapshotCards(p.Deck),
+			Hand:          snapshotCards(p.Hand),
+			Board:         snapshotCards(p.Board),
+			Graveyard:     snapshotCards(p.Graveyard),
+			CurrentEnergy: p.CurrentEnergy,
+			MaxEnergy:     p.MaxEnergy,
+			Mulligans:     p.Mulligans,
+			HandKept:      p.HandKept,
+			Fatigue:       p.Fatigue,
+			DeckedOut:     p.DeckedOut,
+		}
+	}
+
+	if g.Stack != nil {
+		snap.Stack = make([]stackItemSnapshot, len(g.Stack))
+		for i, item := range g.Stack {
+			snap.Stack[i] = stackItemSnapshot{Card: snapshotCard(item.Card), Caster: item.Caster, Targets: item.Targets}
+		}
+	}
+
+	for _, a := range g.History {
+		data, err := MarshalAction(a)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+		snap.History = append(snap.History, data)
+	}
+
+	for i, deck := range g.decks {
+		if deck == nil {
+			continue
+		}
+		snap.DeckLists[i] = make([]string, len(deck))
+		for j := range deck {
+			snap.DeckLists[i][j] = deck[j].ID
+		}
+	}
+
+	return json.Marshal(snap)
+}
+
+// RestoreSnapshot rebuilds a game from MarshalSnapshot output. Card instances
+// are re-linked to definitions by ID from defs; every referenced ID must be
+// present.
+func RestoreSnapshot(data []byte, defs []cards.CardDef) (*Game, error) {
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	if snap.Version != SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, snap.Version)
+	}
+
+	// Token cards travel with the options rather than the deck lists
+	byID, err := indexCardDefs(append(slices.Clone(defs), snap.Options.Tokens...))
+	if err != nil {
+		return nil, err
+	}
+
+	src := restoreCountingSource(snap.RandSeed, snap.RandCalls)
+
+	g := &Game{
+		ID:            snap.ID,
+		Active:        snap.Active,
+		Turn:          snap.Turn,
+		TurnStarted:   snap.TurnStarted,
+		Options:       snap.Options,
+		Rand:          &randAdapter{r: rand.New(src), src: src},
+		Log:           snap.Log,
+		GameEnded:     snap.GameEnded,
+		Winner:        snap.Winner,
+		CombatPhase:   snap.CombatPhase,
+		AttackingIDs:  snap.AttackingIDs,
+		AttackTargets: snap.AttackTargets,
+		BlockingPairs: snap.BlockingPairs,
+		Priority:      snap.Priority,
+		passes:        snap.Passes,
+		EndingTurn:    snap.EndingTurn,
+		decisionSeq:   snap.DecisionSeq,
+		nextInstance:  snap.NextInstance,
+	}
+
+	for i, ps := range snap.Players {
+		p := &PlayerState{
+			PlayerID:      ps.PlayerID,
+			Name:          ps.Name,
+			Life:          ps.Life,
+			CurrentEnergy: ps.CurrentEnergy,
+			MaxEnergy:     ps.MaxEnergy,
+			Mulligans:     ps.Mulligans,
+			HandKept:      ps.HandKept,
+			Fatigue:       ps.Fatigue,
+			DeckedOut:     ps.DeckedOut,
+		}
+		if p.Deck, err = restoreCards(ps.Deck, byID); err != nil {
+			return nil, err
+		}
+		if p.Hand, err = restoreCards(ps.Hand, byID); err != nil {
+			return nil, err
+		}
+		if p.Board, err = restoreCards(ps.Board, byID); err != nil {
+			return nil, err
+		}
+		if p.Graveyard, err = restoreCards(ps.Graveyard, byID); err != nil {
+			return nil, err
+		}
+		g.Players[i] = p
+	}
+
+	if snap.Stack != nil {
+		g.Stack = make([]StackItem, len(snap.Stack))
+		for i, item := range snap.Stack {
+			card, err := restoreCard(item.Card, byID)
+			if err != nil {
+				return nil, err
+			}
+			g.Stack[i] = StackItem{Card: card, Caster: item.Caster, Targets: item.Targets}
+		}
+	}
+
+	if g.Decisions, err = restoreDecisions(snap.Decisions, byID); err != nil {
+		return nil, err
+	}
+
+	for _, raw := range snap.History {
+		a, err := UnmarshalAction(raw)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+		g.History = append(g.History, a)
+	}
+
+	for i, ids := range snap.DeckLists {
+		if ids == nil {
+			continue
+		}
+		g.decks[i] = make([]cards.CardDef, len(ids))
+		for j, id := range ids {
+			def, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q (deck list)", ErrUnknownCardDef, id)
+			}
+			g.decks[i][j] = *def
+		}
+	}
+
+	return g, nil
+}
+
+// indexCardDefs maps card IDs to definitions. The same ID may appear several
+// times (deck lists carry one entry per copy) as long as the entries agree.
+func indexCardDefs(defs []cards.CardDef) (map[string]*cards.CardDef, error) {
+	byID := make(map[string]*cards.CardDef, len(defs))
+	var add func(def cards.CardDef) error
+	add = func(def cards.CardDef) error {
+		if existing, ok := byID[def.ID]; ok {
+			if !reflect.DeepEqual(*existing, def) {
+				return fmt.Errorf("snapshot: conflicting definitions for card %q", def.ID)
+			}
+			return nil
+		}
+		byID[def.ID] = &def
+
+		// Cards a transform turns into are only defined inside the effect
+		for _, into := range transformTargets(&def) {
+			if err := add(*into); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := range defs {
+		if err := add(defs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return byID, nil
+}
+
+// transformTargets lists the Into cards of every effect on def.
+func transformTargets(def *cards.CardDef) []*cards.CardDef {
+	var out []*cards.CardDef
+	walkEffects(def, func(e cards.Effect) {
+		if e.Into != nil {
+			out = append(out, e.Into)
+		}
+	})
+	return out
+}
+
+// walkEffects calls fn for every effect on def, including those of its
+// abilities and the options of choose_one effects.
+func walkEffects(def *cards.CardDef, fn func(cards.Effect)) {
+	var walk func(effects []cards.Effect)
+	walk = func(effects []cards.Effect) {
+		for _, e := range effects {
+			fn(e)
+			walk(e.Choices)
+		}
+	}
+	walk(def.Effects)
+	for _, a := range def.Abilities {
+		walk(a.Effects)
+	}
+}
+
+func snapshotCard(ci CardInstance) cardSnapshot {
+	return cardSnapshot{
+		InstanceID:       ci.InstanceID,
+		DefID:            ci.Def.ID,
+		Owner:            ci.Owner,
+		Controller:       ci.Controller,
+		PermAttackBuff:   ci.PermAttackBuff,
+		PermHealthBuff:   ci.PermHealthBuff,
+		TempAttackBuff:   ci.TempAttackBuff,
+		TempHealthBuff:   ci.TempHealthBuff,
+		AuraAttackBuff:   ci.AuraAttackBuff,
+		AuraHealthBuff:   ci.AuraHealthBuff,
+		CurrentDamage:    ci.CurrentDamage,
+		CurrentAttack:    ci.CurrentAttack,
+		CurrentHealth:    ci.CurrentHealth,
+		SummoningSick:    ci.SummoningSick,
+		Exhausted:        ci.Exhausted,
+		AttackedThisTurn: ci.AttackedThisTurn,
+		DivineShield:     ci.DivineShield,
+		Destroyed:        ci.Destroyed,
+		FrozenUntil:      ci.FrozenUntil,
+		Token:            ci.Token,
+	}
+}
+
+func snapshotCards(insts []CardInstance) []cardSnapshot {
+	if insts == nil {
+		return nil
+	}
+	out := make([]cardSnapshot, len(insts))
+	for i := range insts {
+		out[i] = snapshotCard(insts[i])
+	}
+	return out
+}
+
+func restoreCard(cs cardSnapshot, byID map[string]*cards.CardDef) (CardInstance, error) {
+	def, ok := byID[cs.DefID]
+	if !ok {
+		return CardInstance{}, fmt.Errorf("%w: %q (instance %s)", ErrUnknownCardDef, cs.DefID, cs.InstanceID)
+	}
+	return CardInstance{
+		InstanceID:       cs.InstanceID,
+		Def:              def,
+		Owner:            cs.Owner,
+		Controller:       cs.Controller,
+		PermAttackBuff:   cs.PermAttackBuff,
+		PermHealthBuff:   cs.PermHealthBuff,
+		TempAttackBuff:   cs.TempAttackBuff,
+		TempHealthBuff:   cs.TempHealthBuff,
+		AuraAttackBuff:   cs.AuraAttackBuff,
+		AuraHealthBuff:   cs.AuraHealthBuff,
+		CurrentDamage:    cs.CurrentDamage,
+		CurrentAttack:    cs.CurrentAttack,
+		CurrentHealth:    cs.CurrentHealth,
+		SummoningSick:    cs.SummoningSick,
+		Exhausted:        cs.Exhausted,
+		AttackedThisTurn: cs.AttackedThisTurn,
+		DivineShield:     cs.DivineShield,
+		Destroyed:        cs.Destroyed,
+		FrozenUntil:      cs.FrozenUntil,
+		Token:            cs.Token,
+	}, nil
+}
+
+func restoreCards(snaps []cardSnapshot, byID map[string]*cards.CardDef) ([]CardInstance, error) {
+	if snaps == nil {
+		return nil, nil
+	}
+	out := make([]CardInstance, len(snaps))
+	for i := range snaps {
+		ci, err := restoreCard(snaps[i], byID)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = ci
+	}
+	return out, nil
+}
+
+// snapshotCardRef is snapshotCard for an optional card.
+func snapshotCardRef(ci *CardInstance) *cardSnapshot {
+	if ci == nil {
+		return nil
+	}
+	cs := snapshotCard(*ci)
+	return &cs
+}
+
+func restoreCardRef(cs *cardSnapshot, byID map[string]*cards.CardDef) (*CardInstance, error) {
+	if cs == nil {
+		return nil, nil
+	}
+	ci, err := restoreCard(*cs, byID)
+	if err != nil {
+		return nil, err
+	}
+	return &ci, nil
+}
+
+func snapshotDecisions(ds []*PendingDecision) []decisionSnapshot {
+	if ds == nil {
+		return nil
+	}
+	out := make([]decisionSnapshot, len(ds))
+	for i, d := range ds {
+		out[i] = decisionSnapshot{PendingDecision: *d, SourceCard: snapshotCardRef(d.SourceCard)}
+		for _, de := range d.Then {
+			out[i].Then = append(out[i].Then, deferredSnapshot{DeferredEffect: de, SourceCard: snapshotCardRef(de.SourceCard)})
+		}
+	}
+	return out
+}
+
+func restoreDecisions(snaps []decisionSnapshot, byID map[string]*cards.CardDef) ([]*PendingDecision, error) {
+	if snaps == nil {
+		return nil, nil
+	}
+	out := make([]*PendingDecision, len(snaps))
+	for i, ds := range snaps {
+		d := ds.PendingDecision
+		var err error
+		if d.SourceCard, err = restoreCardRef(ds.SourceCard, byID); err != nil {
+			return nil, err
+		}
+		d.Then = nil
+		for _, dsn := range ds.Then {
+			de := dsn.DeferredEffect
+			if de.SourceCard, err = restoreCardRef(dsn.SourceCard, byID); err != nil {
+				return nil, err
+			}
+			d.Then = append(d.Then, de)
+		}
+		out[i] = &d
+	}
+	return out, nil
+}
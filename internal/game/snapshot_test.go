@@ -0,0 +1,103 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	d1, d2 := makeDeck(20), makeDeck(20)
+	g, err := NewGame("p0", "p1", d1, d2, Options{StartingHand: 3, Seed: 2024})
+	require.NoError(t, err)
+
+	autoPlay(t, g, 6)
+
+	// Leave some mid-combat and buff state lying around
+	require.NoError(t, g.StartTurn())
+	p := g.CurrentPlayer()
+	require.NotEmpty(t, p.Board)
+	p.Board[0].PermAttackBuff = 2
+	p.Board[0].CurrentAttack += 2
+	p.Board[0].DivineShield = true
+	require.NoError(t, g.DeclareAttackers(p.PlayerID, []InstanceID{p.Board[0].InstanceID}))
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+
+	restored, err := RestoreSnapshot(data, append(append([]cards.CardDef{}, d1...), d2...))
+	require.NoError(t, err)
+
+	assert.Equal(t, g, restored)
+}
+
+func TestSnapshot_ResumesRandomSequence(t *testing.T) {
+	d1, d2 := makeDeck(20), makeDeck(20)
+	g, err := NewGame("p0", "p1", d1, d2, Options{StartingHand: 3, Seed: 77})
+	require.NoError(t, err)
+	autoPlay(t, g, 3)
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := RestoreSnapshot(data, append(d1, d2...))
+	require.NoError(t, err)
+
+	for range 20 {
+		assert.Equal(t, g.Rand.Intn(1000), restored.Rand.Intn(1000))
+	}
+
+	autoPlay(t, g, 6)
+	autoPlay(t, restored, 6)
+	assert.Equal(t, g.Log, restored.Log, "both games should continue identically")
+}
+
+func TestSnapshot_StackAndDefLinking(t *testing.T) {
+	g := newStackGame(t)
+	pushDummySpell(t, g)
+	g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wolf", "p1", 2, 2))
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+
+	defs := []cards.CardDef{*g.Stack[0].Card.Def, *g.Players[1].Board[0].Def}
+	defs = append(defs, smallDeck(10)...)
+
+	restored, err := RestoreSnapshot(data, defs)
+	require.NoError(t, err)
+	require.Len(t, restored.Stack, 1)
+	assert.Equal(t, g.Stack[0], restored.Stack[0])
+	assert.Equal(t, 1, restored.Priority)
+
+	// Instances of the same card share a single definition
+	restored.Players[1].Board = append(restored.Players[1].Board, restored.Players[1].Board[0])
+	assert.Same(t, restored.Players[1].Board[0].Def, restored.Players[1].Board[1].Def)
+}
+
+func TestSnapshot_Errors(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, newCreature("a#1", "Bear", "p0", 2, 2))
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+
+	_, err = RestoreSnapshot(data, smallDeck(10))
+	assert.ErrorIs(t, err, ErrUnknownCardDef, "the board creature's def was not supplied")
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	raw["version"] = SnapshotVersion + 1
+	bumped, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	_, err = RestoreSnapshot(bumped, smallDeck(10))
+	assert.ErrorIs(t, err, ErrSnapshotVersion)
+
+	conflicting := smallDeck(2)
+	conflicting[1].ID = conflicting[0].ID
+	_, err = RestoreSnapshot(data, conflicting)
+	assert.Error(t, err)
+}
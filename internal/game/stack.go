@@ -0,0 +1,183 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoPriority     = errors.New("player does not hold priority")
+	ErrStackEmpty     = errors.New("stack is empty")
+	ErrStackNotEmpty  = errors.New("stack is not empty")
+	ErrNotInstantCard = errors.New("card is not instant speed")
+)
+
+// priorityPlayer returns the player allowed to act next. With an empty stack
+// that is the active player, except while blockers are declared: the
+// defending player holds priority then, so they can answer an attack.
+func (g *Game) priorityPlayer() *PlayerState {
+	if len(g.Stack) == 0 {
+		if g.CombatPhase == PhaseBlockers {
+			return g.Opponent()
+		}
+		return g.CurrentPlayer()
+	}
+	return g.Players[g.Priority]
+}
+
+// checkPriority reports whether the caster may play the card right now.
+// Normal speed cards need the caster's own turn and an empty stack, so they
+// can't be cast in response to a spell; instants only need priority (see
+// priorityPlayer).
+func (g *Game) checkPriority(caster *PlayerState, card CardInstance) error {
+	if !card.Def.IsInstant() {
+		if g.CurrentPlayer() != caster {
+			return ErrNotYourTurn
+		}
+		if len(g.Stack) > 0 {
+			return fmt.Errorf("%w: %s", ErrNotInstantCard, card.Def.ID)
+		}
+		return nil
+	}
+
+	if g.priorityPlayer() != caster {
+		return ErrNoPriority
+	}
+	return nil
+}
+
+// pushStack puts a cast spell on top of the stack and hands priority to the
+// caster's opponent.
+func (g *Game) pushStack(caster *PlayerState, card CardInstance, targets []*TargetRef) {
+	g.Stack = append(g.Stack, StackItem{Card: card, Caster: caster.PlayerID, Targets: targets})
+	g.Priority = g.playerIndex(g.opponentOf(caster))
+	g.passes = 0
+	g.log("stack", caster.PlayerID, "%s (%s) put on the stack", card.Def.Name, card.InstanceID)
+}
+
+// PassPriorityAction declines to respond to the top of the stack. Once both
+// players pass in succession the top item resolves and the active player
+// receives priority again.
+type PassPriorityAction struct {
+	PlayerID string `json:"player_id"`
+}
+
+func (a PassPriorityAction) Validate(g *Game) error {
+	if len(g.Stack) == 0 {
+		return ErrStackEmpty
+	}
+
+	if g.priorityPlayer().PlayerID != a.PlayerID {
+		return ErrNoPriority
+	}
+
+	return nil
+}
+
+func (a PassPriorityAction) Apply(g *Game) error {
+	g.passes++
+	g.log("pass", a.PlayerID, "passed priority")
+
+	if g.passes < 2 {
+		g.Priority = 1 - g.Priority
+		return nil
+	}
+
+	g.resolveTop()
+	g.Priority = g.Active
+	g.passes = 0
+	g.resolveStateBasedEffects()
+
+	return nil
+}
+
+func (g *Game) PassPriority(playerID string) error {
+	return g.Submit(PassPriorityAction{PlayerID: playerID})
+}
+
+// resolveStack resolves every item on the stack, top first, as if both
+// players kept passing. Nothing more resolves once the game has ended.
+func (g *Game) resolveStack() {
+	for len(g.Stack) > 0 && !g.GameEnded {
+		g.resolveTop()
+	}
+	g.Priority = g.Active
+	g.passes = 0
+}
+
+// resolveTop pops the top stack item and resolves its effects through
+// effectResolver. Targets are re-checked first: effects whose target became
+// illegal are skipped, and if every targeted effect lost its target the whole
+// spell fizzles.
+func (g *Game) resolveTop() {
+	top := len(g.Stack) - 1
+	item := g.Stack[top]
+	g.Stack = g.Stack[:top]
+
+	card := item.Card
+	caster := g.playerByID(item.Caster)
+
+	legal := make([]bool, len(card.Def.Effects))
+	targeted, stillLegal := 0, 0
+	for i, effect := range card.Def.Effects {
+		if engineTargeted(effect.Target) {
+			legal[i] = true
+			continue
+		}
+		targeted++
+		if g.validateEffectTarget(effect, item.Targets[i], caster) == nil {
+			legal[i] = true
+			stillLegal++
+		}
+	}
+
+	if targeted > 0 && stillLegal == 0 {
+		g.log("fizzle", caster.PlayerID, "%s (%s) fizzled: no legal targets", card.Def.Name, card.InstanceID)
+		g.putInGraveyard(card)
+		return
+	}
+
+	g.log("resolve", caster.PlayerID, "%s (%s) resolves", card.Def.Name, card.InstanceID)
+
+	var effects []DeferredEffect
+	for i, effect := range card.Def.Effects {
+		if !legal[i] {
+			g.log("fizzle", caster.PlayerID, "%s effect %d skipped: target no longer legal", card.Def.Name, i)
+			continue
+		}
+		actualTarget := g.autoPopulateTarget(effect, item.Targets[i], caster)
+		effects = append(effects, DeferredEffect{Effect: effect, Target: actualTarget, Caster: caster.PlayerID, Source: card.InstanceID, SourceCard: &card})
+	}
+	g.runEffects(effects, &card, card.Def.Name)
+
+	g.putInGraveyard(card)
+}
+
+// findStackItem returns the index of the stack item for the given card.
+func (g *Game) findStackItem(id InstanceID) (int, bool) {
+	for i := range g.Stack {
+		if g.Stack[i].Card.InstanceID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// applyCounter removes the targeted spell from the stack without resolving it.
+func applyCounter(ctx *EffectContext) error {
+	if ctx.Target == nil || ctx.Target.InstanceID == nil {
+		return fmt.Errorf("applyCounter: %w", ErrMissingTarget)
+	}
+
+	i, ok := ctx.Game.findStackItem(*ctx.Target.InstanceID)
+	if !ok {
+		return fmt.Errorf("applyCounter: %w", ErrInvalidTarget)
+	}
+
+	countered := ctx.Game.Stack[i].Card
+	ctx.Game.Stack = append(ctx.Game.Stack[:i], ctx.Game.Stack[i+1:]...)
+	ctx.Game.log("counter", ctx.Caster.PlayerID, "%s (%s) was countered", countered.Def.Name, countered.InstanceID)
+	ctx.Game.putInGraveyard(countered)
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestStack_SpellWaitsForBothPasses(t *testing.T) {
+	g := newStackGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p1.Board = append(p1.Board, newCreature("b#1", "Wolf", "p1", 2, 2))
+	p0.Hand = append(p0.Hand, spellCard("bolt#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyCreature}))
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("b#1")}}))
+
+	require.Len(t, g.Stack, 1)
+	assert.Len(t, p1.Board, 1, "spell must not resolve before priority is passed")
+	assert.Equal(t, p1, g.priorityPlayer())
+
+	assert.ErrorIs(t, g.PassPriority("p0"), ErrNoPriority)
+	require.NoError(t, g.PassPriority("p1"))
+	assert.Len(t, g.Stack, 1, "one pass is not enough")
+	require.NoError(t, g.PassPriority("p0"))
+
+	assert.Empty(t, g.Stack)
+	assert.Empty(t, p1.Board)
+	assert.Len(t, p0.Graveyard, 1, "resolved spell goes to the graveyard")
+	assert.Equal(t, p0, g.priorityPlayer())
+	assert.ErrorIs(t, g.PassPriority("p0"), ErrStackEmpty)
+}
+
+func TestStack_Counterspell(t *testing.T) {
+	g := newStackGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Hand = append(p0.Hand, spellCard("burn#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 5, Target: cards.TargetEnemyPlayer}))
+	p1.Hand = append(p1.Hand, spellCard("counter#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectCounter, Target: cards.TargetStackSpell}))
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{nil}))
+	require.NoError(t, g.PlayCard("p1", 0, []*TargetRef{{InstanceID: ptrInstance("burn#1")}}))
+	require.Len(t, g.Stack, 2)
+	assert.Equal(t, p0, g.priorityPlayer(), "priority goes back to the original caster")
+
+	require.NoError(t, g.PassPriority("p0"))
+	require.NoError(t, g.PassPriority("p1"))
+
+	assert.Empty(t, g.Stack, "countered spell is removed from the stack")
+	assert.Equal(t, 20, p1.Life, "countered spell must not resolve")
+	assert.Len(t, p0.Graveyard, 1)
+	assert.Len(t, p1.Graveyard, 1)
+	assert.Equal(t, 9, p1.CurrentEnergy, "instants are paid from the responder's energy")
+}
+
+func TestStack_FizzlesWhenTargetIsGone(t *testing.T) {
+	g := newStackGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Bear", "p0", 2, 2))
+	p0.Hand = append(p0.Hand, spellCard("growth#1", "p0", "", cards.Effect{Kind: cards.EffectBuffStatsPerm, BuffAttack: 3, BuffHealth: 3, Target: cards.TargetAllyCreature}))
+	p1.Hand = append(p1.Hand, spellCard("shock#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 2, Target: cards.TargetEnemyCreature}))
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("a#1")}}))
+	require.NoError(t, g.PlayCard("p1", 0, []*TargetRef{{InstanceID: ptrInstance("a#1")}}))
+
+	// Top of stack (the response) resolves first and kills the target
+	require.NoError(t, g.PassPriority("p0"))
+	require.NoError(t, g.PassPriority("p1"))
+	assert.Empty(t, p0.Board)
+	require.Len(t, g.Stack, 1)
+
+	require.NoError(t, g.PassPriority("p0"))
+	require.NoError(t, g.PassPriority("p1"))
+	assert.Empty(t, g.Stack)
+
+	var fizzled bool
+	for _, e := range g.Log {
+		if e.Type == "fizzle" {
+			fizzled = true
+		}
+	}
+	assert.True(t, fizzled, "buff should fizzle without a legal target")
+	assert.Len(t, p0.Graveyard, 2, "dead creature and fizzled spell")
+}
+
+func TestStack_TimingRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		setup    func(t *testing.T, g *Game)
+		playerID string
+		card     CardInstance
+		targets  []*TargetRef
+		wantErr  error
+	}{
+		{
+			name:     "instant on the opponent's turn needs priority",
+			playerID: "p1",
+			card:     spellCard("x#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  ErrNoPriority,
+		},
+		{
+			name:     "normal spell on the opponent's turn",
+			playerID: "p1",
+			setup:    pushDummySpell,
+			card:     spellCard("x#1", "p1", "", cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  ErrNotYourTurn,
+		},
+		{
+			name:     "instant in response with priority",
+			playerID: "p1",
+			setup:    pushDummySpell,
+			card:     spellCard("x#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  nil,
+		},
+		{
+			name:     "normal speed cannot respond on own turn",
+			playerID: "p0",
+			setup: func(t *testing.T, g *Game) {
+				pushDummySpell(t, g)
+				g.Priority = 0
+			},
+			card:    spellCard("x#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets: []*TargetRef{nil},
+			wantErr: ErrNotInstantCard,
+		},
+		{
+			name:     "instant on own turn with empty stack",
+			playerID: "p0",
+			card:     spellCard("x#1", "p0", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  nil,
+		},
+		{
+			name:     "instant while declaring blockers",
+			playerID: "p1",
+			setup:    func(t *testing.T, g *Game) { g.CombatPhase = PhaseBlockers },
+			card:     spellCard("x#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  nil,
+		},
+		{
+			name:     "attacker's instant while blockers are declared",
+			playerID: "p0",
+			setup:    func(t *testing.T, g *Game) { g.CombatPhase = PhaseBlockers },
+			card:     spellCard("x#1", "p0", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}),
+			targets:  []*TargetRef{nil},
+			wantErr:  ErrNoPriority,
+		},
+		{
+			name:     "counter needs a spell on the stack",
+			playerID: "p0",
+			card:     spellCard("x#1", "p0", cards.SpeedInstant, cards.Effect{Kind: cards.EffectCounter, Target: cards.TargetStackSpell}),
+			targets:  []*TargetRef{{InstanceID: ptrInstance("nothing#1")}},
+			wantErr:  ErrInvalidTarget,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newStackGame(t)
+			if tc.setup != nil {
+				tc.setup(t, g)
+			}
+			player := g.playerByID(tc.playerID)
+			player.Hand = []CardInstance{tc.card}
+
+			err := g.CanPlayCard(tc.playerID, 0, tc.targets)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestStack_ResolvesImmediatelyWithoutPriorityPassing(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("burn#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 5, Target: cards.TargetEnemyPlayer}))
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{nil}))
+
+	assert.Empty(t, g.Stack)
+	assert.Equal(t, 15, g.Players[1].Life)
+}
+
+func TestStack_DefenderAnswersAnAttack(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board, newCreature("a#1", "Raider", "p0", 3, 3))
+	p1.MaxEnergy, p1.CurrentEnergy = 1, 1
+	p1.Hand = append(p1.Hand, spellCard("x#1", "p1", cards.SpeedInstant, cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetAnyCreature}))
+
+	assert.ErrorIs(t, g.CanPlayCard("p1", 0, []*TargetRef{{InstanceID: ptrInstance("a#1")}}), ErrNoPriority, "not before the attack")
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.PlayCard("p1", 0, []*TargetRef{{InstanceID: ptrInstance("a#1")}}))
+	assert.Empty(t, p0.Board)
+
+	require.NoError(t, g.DeclareBlockers("p1", nil))
+	require.NoError(t, g.ResolveCombat("p0"))
+	assert.Equal(t, 20, p1.Life)
+}
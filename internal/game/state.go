@@ -24,13 +24,51 @@ const (
 
 type InstanceID string
 
+type DeckOutRule string
+
+const (
+	DeckOutNone      DeckOutRule = ""          // the draw is skipped
+	DeckOutLose      DeckOutRule = "lose"      // the player loses
+	DeckOutFatigue   DeckOutRule = "fatigue"   // 1 damage, then 2, 3... for each missed draw
+	DeckOutReshuffle DeckOutRule = "reshuffle" // the graveyard is shuffled back in as the new deck
+)
+
 type Options struct {
-	StartingLife     int
-	StartingHand     int
-	MaxEnergy        int
-	MaxBoardSize     int
-	FirstPlayerDraws bool
-	Seed             int64
+	StartingLife     int   `json:"starting_life,omitempty"`
+	StartingHand     int   `json:"starting_hand,omitempty"`
+	MaxEnergy        int   `json:"max_energy,omitempty"`
+	MaxBoardSize     int   `json:"max_board_size,omitempty"`
+	FirstPlayerDraws bool  `json:"first_player_draws,omitempty"`
+	Seed             int64 `json:"seed,omitempty"`
+
+	// PriorityPassing keeps spells on the stack until both players pass in
+	// succession, giving the opponent a window to respond with instants.
+	// When false every spell resolves as soon as it is cast.
+	PriorityPassing bool `json:"priority_passing,omitempty"`
+
+	// DeckOut decides what happens when a player has to draw from an empty
+	// deck. The default is to skip the draw.
+	DeckOut DeckOutRule `json:"deck_out,omitempty"`
+
+	// Mulligan picks how players may redraw their opening hand. Any style
+	// other than MulliganNone holds the game until both players keep.
+	Mulligan MulliganStyle `json:"mulligan,omitempty"`
+
+	// MaxHandSize caps the hand when positive. HandLimit picks what happens
+	// to extra cards; the default is HandLimitBurn.
+	MaxHandSize int             `json:"max_hand_size,omitempty"`
+	HandLimit   HandLimitPolicy `json:"hand_limit,omitempty"`
+
+	// DecisionTimeoutMs is how long a server waits for a player decision
+	// before applying its default answer. Zero waits forever.
+	DecisionTimeoutMs int `json:"decision_timeout_ms,omitempty"`
+
+	// Tokens defines the cards summon effects may create. They are never
+	// part of a deck.
+	Tokens []cards.CardDef `json:"tokens,omitempty"`
+
+	// DeckRules, when set, makes NewGame reject decks that break them.
+	DeckRules *cards.DeckRules `json:"deck_rules,omitempty"`
 }
 
 type CardInstance struct {
@@ -43,17 +81,33 @@ type CardInstance struct {
 	PermAttackBuff int
 	PermHealthBuff int
 
+	// Buffs from the auras of creatures on the board, worked out by
+	// recomputeStats
+	AuraAttackBuff int
+	AuraHealthBuff int
+
 	// Temporary effects to attack/health
 	TempAttackBuff int
 	TempHealthBuff int
 	CurrentDamage  int
 
-	// Current calculated values for attack/health
+	// Current calculated values for attack/health, see computeStats
 	CurrentAttack int
 	CurrentHealth int
 
 	SummoningSick bool
 	Exhausted     bool
+	// Set when the creature attacks, so one that vigilance keeps ready
+	// still attacks only once a turn
+	AttackedThisTurn bool
+
+	// Keyword state
+	DivineShield bool // shield is still up
+	Destroyed    bool // marked for destruction (e.g. poisonous), removed by state-based effects
+
+	FrozenUntil int // when set, can't attack until Game.Turn is past this
+
+	Token bool // created by an effect; ceases to exist when it leaves the board
 }
 
 type PlayerState struct {
@@ -67,20 +121,43 @@ type PlayerState struct {
 
 	CurrentEnergy int
 	MaxEnergy     int
+
+	// Mulligan phase progress
+	Mulligans int
+	HandKept  bool
+
+	// Deck-out state
+	Fatigue   int  // fatigue damage dealt by the last missed draw
+	DeckedOut bool // drew from an empty deck under DeckOutLose
 }
 
 type Event struct {
-	Turn   int
-	Player string
-	Type   string
-	Msg    string
+	Turn   int    `json:"turn"`
+	Player string `json:"player,omitempty"`
+	Type   string `json:"type"`
+	Msg    string `json:"msg"`
+
+	// Private holds details only Player may see, such as the names of drawn
+	// cards. Views for anyone else drop it.
+	Private string `json:"private,omitempty"`
+}
+
+// StackItem is a cast spell waiting to resolve.
+type StackItem struct {
+	Card    CardInstance
+	Caster  string
+	Targets []*TargetRef
 }
 
 type Game struct {
-	ID        string
-	Players   [2]*PlayerState
-	Active    int
-	Turn      int
+	ID      string
+	Players [2]*PlayerState
+	Active  int
+	Turn    int
+
+	// TurnStarted is set once the active player has started their turn
+	TurnStarted bool
+
 	Options   Options
 	Rand      randSource
 	Log       []Event
@@ -90,9 +167,29 @@ type Game struct {
 	// Combat state tracking
 	CombatPhase   CombatPhase
 	AttackingIDs  []InstanceID
+	AttackTargets map[InstanceID]InstanceID // attacker -> creature it attacks
 	BlockingPairs map[InstanceID]InstanceID // attacker -> blocker
+
+	// Spell stack (last in, first out) and priority
+	Stack    []StackItem
+	Priority int // index of the player who may act while the stack is non-empty
+
+	// Decisions the game is waiting on, asked in order. EndingTurn is set
+	// while an EndTurn waits for them to be answered.
+	Decisions  []*PendingDecision
+	EndingTurn bool
+
+	// History lists every action applied through Submit, in order
+	History []Action
+
+	decks        [2][]cards.CardDef // deck lists the game was created with
+	passes       int                // consecutive priority passes since the stack last changed
+	triggerDepth int                // nesting of triggered abilities currently resolving
+	decisionSeq  int                // ID of the last decision asked
+	nextInstance int                // number of the last card instance created
 }
 
 type randSource interface {
 	Intn(n int) int
+	Shuffle(n int, swap func(i, j int))
 }
@@ -0,0 +1,65 @@
+package game
+
+import "github.com/AdonaIsium/tcg-engine/internal/cards"
+
+// Stats are worked out in layers, each applied on top of the one before:
+//
+//  1. the card's base attack and health
+//  2. permanent buffs
+//  3. auras of creatures on the board
+//  4. temporary buffs, which last until the end of the turn
+//  5. damage, which only lowers health
+//
+// Only the aura layer depends on other cards, so recomputeStats redoes it
+// for the whole board while computeStats stacks the layers of one card.
+
+// recomputeStats recomputes the stats of every creature on the board. It runs
+// after every state change, so auras switch on and off as creatures enter
+// and leave; creatures they no longer keep alive die to state-based effects.
+func (g *Game) recomputeStats() {
+	for _, ps := range g.Players {
+		for i := range ps.Board {
+			ci := &ps.Board[i]
+			ci.AuraAttackBuff, ci.AuraHealthBuff = g.auraBuffs(ci)
+			computeStats(ci)
+		}
+	}
+}
+
+// computeStats sets the current stats of ci from its layers, taking the aura
+// layer as last worked out by recomputeStats. Attack can't go below 0.
+func computeStats(ci *CardInstance) {
+	ci.CurrentAttack = max(ci.Def.Attack+ci.PermAttackBuff+ci.AuraAttackBuff+ci.TempAttackBuff, 0)
+	ci.CurrentHealth = ci.Def.Health + ci.PermHealthBuff + ci.AuraHealthBuff + ci.TempHealthBuff - ci.CurrentDamage
+}
+
+// auraBuffs sums the auras on the board that reach ci.
+func (g *Game) auraBuffs(ci *CardInstance) (attack, health int) {
+	for _, ps := range g.Players {
+		for i := range ps.Board {
+			source := &ps.Board[i]
+			for _, aura := range source.Def.Auras {
+				if auraReaches(aura.Scope, source, ci) {
+					attack += aura.BuffAttack
+					health += aura.BuffHealth
+				}
+			}
+		}
+	}
+	return attack, health
+}
+
+func auraReaches(scope cards.AuraScope, source, ci *CardInstance) bool {
+	ally := source.Controller == ci.Controller
+	switch scope {
+	case cards.AuraOtherAllyCreatures:
+		return ally && source.InstanceID != ci.InstanceID
+	case cards.AuraAllyCreatures:
+		return ally
+	case cards.AuraEnemyCreatures:
+		return !ally
+	case cards.AuraAllCreatures:
+		return true
+	}
+	return false
+}
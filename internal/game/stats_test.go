@@ -0,0 +1,126 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestStats_AuraFollowsItsCreature(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Board = append(p0.Board, newCreature("a#1", "Footman", "p0", 1, 1))
+	p0.Hand = append(p0.Hand, withAura(newCreature("l#1", "Leader", "p0", 2, 2), cards.AuraOtherAllyCreatures, 1, 1))
+
+	require.NoError(t, g.PlayCard("p0", 0, nil))
+	assert.Equal(t, 2, p0.Board[0].CurrentAttack)
+	assert.Equal(t, 2, p0.Board[0].CurrentHealth)
+	assert.Equal(t, 2, p0.Board[1].CurrentAttack, "other creatures only")
+
+	// A creature entering later is covered too
+	p0.Hand = append(p0.Hand, newCreature("a#2", "Archer", "p0", 1, 1))
+	require.NoError(t, g.PlayCard("p0", 0, nil))
+	assert.Equal(t, 2, p0.Board[2].CurrentAttack)
+
+	p0.Hand = append(p0.Hand, spellCard("s#1", "p0", "", cards.Effect{Kind: cards.EffectReturnToHand, Target: cards.TargetAllyCreature}))
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("l#1")}}))
+	assert.Equal(t, 1, p0.Board[0].CurrentAttack)
+	assert.Equal(t, 1, p0.Board[0].CurrentHealth)
+	assert.Equal(t, 2, p0.Hand[0].CurrentAttack, "back in hand with its printed stats")
+}
+
+func TestStats_Scopes(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.Board = append(p0.Board,
+		withAura(newCreature("a#1", "Banner", "p0", 1, 3), cards.AuraAllyCreatures, 1, 0),
+		newCreature("a#2", "Footman", "p0", 1, 3),
+	)
+	p1.Board = append(p1.Board,
+		withAura(newCreature("e#1", "Gloom", "p1", 2, 3), cards.AuraEnemyCreatures, -2, 0),
+		withAura(newCreature("e#2", "Beacon", "p1", 1, 3), cards.AuraAllCreatures, 0, 1),
+	)
+
+	g.recomputeStats()
+	assert.Equal(t, 0, p0.Board[0].CurrentAttack, "1 + 1 - 2")
+	assert.Equal(t, 0, p0.Board[1].CurrentAttack)
+	assert.Equal(t, 4, p0.Board[1].CurrentHealth)
+	assert.Equal(t, 2, p1.Board[0].CurrentAttack)
+	assert.Equal(t, 4, p1.Board[1].CurrentHealth, "all creatures includes its own")
+
+	// Attack stops at 0 rather than going negative
+	p0.Board[1].TempAttackBuff = -3
+	g.recomputeStats()
+	assert.Equal(t, 0, p0.Board[1].CurrentAttack)
+}
+
+func TestStats_LosingAnAuraCanKill(t *testing.T) {
+	g := newCombatGame(t)
+	p1 := g.Players[1]
+	p1.Board = append(p1.Board,
+		withAura(newCreature("e#1", "Warden", "p1", 1, 1), cards.AuraOtherAllyCreatures, 0, 2),
+		newCreature("e#2", "Squire", "p1", 1, 1),
+	)
+
+	g.recomputeStats()
+	g.damageCreature(nil, &p1.Board[1], 2)
+	assert.Equal(t, 1, p1.Board[1].CurrentHealth, "kept alive by the aura")
+
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("s#1", "p0", "", cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetEnemyCreature}))
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("e#1")}}))
+	assert.Empty(t, p1.Board)
+	assert.Equal(t, []string{"e#1", "e#2"}, collectIDs(p1.Graveyard))
+}
+
+func TestStats_Layers(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board,
+		withAura(newCreature("a#1", "Captain", "p0", 1, 1), cards.AuraOtherAllyCreatures, 1, 1),
+		newCreature("a#2", "Knight", "p0", 2, 4),
+	)
+	g.recomputeStats()
+	g.damageCreature(nil, &p0.Board[1], 3)
+	assert.Equal(t, 2, p0.Board[1].CurrentHealth, "4 + 1 - 3")
+
+	// Buffs go under the damage instead of healing it
+	castSpell(t, g,
+		cards.Effect{Kind: cards.EffectBuffStatsPerm, BuffAttack: 1, BuffHealth: 1, Target: cards.TargetAllAllyCreatures},
+		cards.Effect{Kind: cards.EffectBuffStatsTemp, BuffAttack: 2, BuffHealth: 2, Target: cards.TargetAllAllyCreatures},
+	)
+	knight := &p0.Board[1]
+	assert.Equal(t, 6, knight.CurrentAttack, "2 + 1 + 1 + 2")
+	assert.Equal(t, 5, knight.CurrentHealth, "4 + 1 + 1 + 2 - 3")
+
+	// Cleanup drops temporary buffs and damage but not the aura
+	g.CleanupTurn()
+	assert.Equal(t, 4, knight.CurrentAttack)
+	assert.Equal(t, 6, knight.CurrentHealth)
+
+	// Silence strips buffs; auras come from the board and stay
+	castSpell(t, g, cards.Effect{Kind: cards.EffectSilence, Target: cards.TargetAllAllyCreatures})
+	assert.Equal(t, 3, knight.CurrentAttack)
+	assert.Equal(t, 5, knight.CurrentHealth)
+}
+
+func TestStats_Snapshot(t *testing.T) {
+	g := newCombatGame(t)
+	captain := withAura(newCreature("a#1", "Captain", "p0", 1, 1), cards.AuraOtherAllyCreatures, 1, 1)
+	knight := newCreature("a#2", "Knight", "p0", 2, 4)
+	g.Players[0].Board = append(g.Players[0].Board, captain, knight)
+	g.recomputeStats()
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := RestoreSnapshot(data, append(smallDeck(10), *captain.Def, *knight.Def))
+	require.NoError(t, err)
+
+	assert.Equal(t, g.Players[0].Board, restored.Players[0].Board)
+	assert.Equal(t, 1, restored.Players[0].Board[1].AuraAttackBuff)
+}
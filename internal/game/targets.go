@@ -1,14 +1,17 @@
 package game
 
 import (
+	"fmt"
+
 	"github.com/AdonaIsium/tcg-engine/internal/cards"
 )
 
 // TargetRef is a reference to something in the game state
 // that a spell/effect can point at.
 type TargetRef struct {
-	PlayerID   string      // if targeting a player
-	InstanceID *InstanceID // if targeting a creature
+	PlayerID    string       `json:"player_id,omitempty"`    // if targeting a player
+	InstanceID  *InstanceID  `json:"instance_id,omitempty"`  // if targeting a creature or a spell on the stack
+	InstanceIDs []InstanceID `json:"instance_ids,omitempty"` // creatures picked for an up_to_n_targets effect
 }
 
 // validateTarget checks that the given TargetRef satisfies
@@ -57,11 +60,59 @@ func (g *Game) validateTarget(req cards.TargetKind, target *TargetRef, caster *P
 
 	// Opponent player
 	case cards.TargetEnemyPlayer:
-		opp := g.Opponent()
+		opp := g.opponentOf(caster)
 		if target == nil || target.PlayerID != opp.PlayerID {
 			return ErrInvalidTarget
 		}
 
+	// Spell waiting on the stack
+	case cards.TargetStackSpell:
+		if target == nil || target.InstanceID == nil {
+			return ErrMissingTarget
+		}
+		if _, ok := g.findStackItem(*target.InstanceID); !ok {
+			return ErrInvalidTarget
+		}
+
+	// Card in the caster's graveyard
+	case cards.TargetAllyGraveyard:
+		if target == nil || target.InstanceID == nil {
+			return ErrMissingTarget
+		}
+		if p, _, ok := g.findInGraveyard(*target.InstanceID); !ok || p != caster {
+			return ErrInvalidTarget
+		}
+
+	// Card in the opponent's graveyard
+	case cards.TargetEnemyGraveyard:
+		if target == nil || target.InstanceID == nil {
+			return ErrMissingTarget
+		}
+		if p, _, ok := g.findInGraveyard(*target.InstanceID); !ok || p == caster {
+			return ErrInvalidTarget
+		}
+
+	// Picked by the engine when the effect resolves
+	case cards.TargetAllEnemyCreatures, cards.TargetAllAllyCreatures, cards.TargetAllCreatures,
+		cards.TargetAllEnemyCharacters, cards.TargetAllAllyCharacters, cards.TargetAllCharacters,
+		cards.TargetRandomEnemyCreature:
+
+	// Any number of distinct creatures, possibly none
+	case cards.TargetUpToN:
+		if target == nil {
+			return nil
+		}
+		if target.InstanceID != nil || target.PlayerID != "" {
+			return ErrInvalidTarget
+		}
+		seen := make(map[InstanceID]bool, len(target.InstanceIDs))
+		for _, id := range target.InstanceIDs {
+			if _, ok := g.findCardInstance(id); !ok || seen[id] {
+				return ErrInvalidTarget
+			}
+			seen[id] = true
+		}
+
 	// Unknown/unsupported target kind
 	default:
 		return ErrInvalidTarget
@@ -70,6 +121,42 @@ func (g *Game) validateTarget(req cards.TargetKind, target *TargetRef, caster *P
 	return nil
 }
 
+// engineTargeted reports whether the engine picks the targets of kind on its
+// own, so players leave them nil.
+func engineTargeted(kind cards.TargetKind) bool {
+	switch kind {
+	case cards.TargetSelfPlayer, cards.TargetEnemyPlayer,
+		cards.TargetAllEnemyCreatures, cards.TargetAllAllyCreatures, cards.TargetAllCreatures,
+		cards.TargetAllEnemyCharacters, cards.TargetAllAllyCharacters, cards.TargetAllCharacters,
+		cards.TargetRandomEnemyCreature:
+		return true
+	}
+	return false
+}
+
+// validateEffectTarget is validateTarget plus the checks a particular effect
+// kind adds, such as resurrect only taking creature cards.
+func (g *Game) validateEffectTarget(effect cards.Effect, target *TargetRef, caster *PlayerState) error {
+	if err := g.validateTarget(effect.Target, target, caster); err != nil {
+		return err
+	}
+
+	if effect.Target == cards.TargetUpToN && target != nil && len(target.InstanceIDs) > effect.MaxTargets {
+		return fmt.Errorf("%w: %d targets, at most %d", ErrInvalidTarget, len(target.InstanceIDs), effect.MaxTargets)
+	}
+
+	if effect.Kind == cards.EffectResurrect {
+		if target == nil || target.InstanceID == nil {
+			return ErrMissingTarget
+		}
+		p, i, ok := g.findInGraveyard(*target.InstanceID)
+		if !ok || p.Graveyard[i].Def.Type != cards.TypeCreature {
+			return ErrInvalidTarget
+		}
+	}
+	return nil
+}
+
 // findCardInstance searches all boards for a card with the given instance ID.
 func (g *Game) findCardInstance(id InstanceID) (*CardInstance, bool) {
 	for _, p := range g.Players {
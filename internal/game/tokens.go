@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+var ErrUnknownToken = errors.New("unknown token card")
+
+// checkTokens makes sure every token is a valid creature and every summon
+// effect in the decks names one of them.
+func checkTokens(tokens []cards.CardDef, decks ...[]cards.CardDef) error {
+	known := make(map[string]bool, len(tokens))
+	for i := range tokens {
+		if err := tokens[i].Validate(); err != nil {
+			return fmt.Errorf("token %q: %w", tokens[i].ID, err)
+		}
+		if tokens[i].Type != cards.TypeCreature {
+			return fmt.Errorf("token %q: tokens must be creatures", tokens[i].ID)
+		}
+		known[tokens[i].ID] = true
+	}
+
+	var errs []error
+	check := func(def *cards.CardDef) {
+		walkEffects(def, func(e cards.Effect) {
+			if e.Kind == cards.EffectSummon && !known[e.CardID] {
+				errs = append(errs, fmt.Errorf("%w: %q (card %s)", ErrUnknownToken, e.CardID, def.ID))
+			}
+		})
+	}
+	for _, deck := range decks {
+		for i := range deck {
+			check(&deck[i])
+		}
+	}
+	for i := range tokens {
+		check(&tokens[i])
+	}
+	return errors.Join(errs...)
+}
+
+// tokenDef looks up a token card in the game options.
+func (g *Game) tokenDef(id string) *cards.CardDef {
+	for i := range g.Options.Tokens {
+		if g.Options.Tokens[i].ID == id {
+			return &g.Options.Tokens[i]
+		}
+	}
+	return nil
+}
+
+// newInstanceID numbers a new card instance. Numbers are unique for the whole
+// game, so instances created mid-game never clash with deck cards.
+func (g *Game) newInstanceID(base string) InstanceID {
+	g.nextInstance++
+	return InstanceID(fmt.Sprintf("%s#%d", base, g.nextInstance))
+}
+
+// newInstances turns a deck list into card instances owned by playerID.
+func (g *Game) newInstances(playerID string, defs []cards.CardDef) []CardInstance {
+	out := make([]CardInstance, 0, len(defs))
+	for i := range defs {
+		def := &defs[i]
+		out = append(out, CardInstance{
+			InstanceID:    g.newInstanceID(def.ID),
+			Def:           def,
+			Owner:         playerID,
+			Controller:    playerID,
+			CurrentAttack: def.Attack,
+			CurrentHealth: def.Health,
+		})
+	}
+	return out
+}
+
+// applySummon puts ctx.Amount (at least one) copies of a token onto the
+// target player's board. Tokens that don't fit are not created.
+func applySummon(ctx *EffectContext) error {
+	g := ctx.Game
+	if ctx.Target == nil || ctx.Target.PlayerID == "" {
+		return fmt.Errorf("applySummon: %w", ErrMissingTarget)
+	}
+	player := g.playerByID(ctx.Target.PlayerID)
+	if player == nil {
+		return fmt.Errorf("applySummon: %w", ErrPlayerNotFound)
+	}
+	def := g.tokenDef(ctx.CardID)
+	if def == nil {
+		return fmt.Errorf("applySummon: %w: %q", ErrUnknownToken, ctx.CardID)
+	}
+
+	for range max(ctx.Amount, 1) {
+		if g.Options.MaxBoardSize > 0 && len(player.Board) >= g.Options.MaxBoardSize {
+			g.log("summon", ctx.Caster.PlayerID, "%s not summoned: %s's board is full", def.Name, player.PlayerID)
+			break
+		}
+
+		player.Board = append(player.Board, CardInstance{
+			InstanceID:    g.newInstanceID(def.ID),
+			Def:           def,
+			Owner:         player.PlayerID,
+			Controller:    player.PlayerID,
+			CurrentAttack: def.Attack,
+			CurrentHealth: def.Health,
+			SummoningSick: !def.HasKeyword(cards.KeywordHaste),
+			DivineShield:  def.HasKeyword(cards.KeywordDivineShield),
+			Token:         true,
+		})
+		g.log("summon", ctx.Caster.PlayerID, "%s (%s) summoned for %s", def.Name, player.Board[len(player.Board)-1].InstanceID, player.PlayerID)
+	}
+	return nil
+}
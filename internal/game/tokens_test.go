@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+var summonWolves = cards.Effect{Kind: cards.EffectSummon, Amount: 2, CardID: "t_wolf", Target: cards.TargetSelfPlayer}
+
+func TestSummon(t *testing.T) {
+	g := newTokenGame(t, Options{MaxBoardSize: 3})
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board, newCreature("a#1", "Ally", "p0", 1, 1))
+
+	castSpell(t, g, summonWolves)
+	require.Len(t, p0.Board, 3)
+	wolf := p0.Board[1]
+	assert.Equal(t, InstanceID("t_wolf#21"), wolf.InstanceID, "numbered after the 20 deck cards")
+	assert.Equal(t, InstanceID("t_wolf#22"), p0.Board[2].InstanceID)
+	assert.True(t, wolf.Token)
+	assert.True(t, wolf.SummoningSick)
+	assert.Equal(t, "p0", wolf.Owner)
+	assert.Equal(t, 2, wolf.CurrentAttack)
+
+	// The board is full: nothing is summoned and nothing fails
+	castSpell(t, g, summonWolves)
+	assert.Len(t, p0.Board, 3)
+}
+
+func TestSummon_TokenLeavesPlay(t *testing.T) {
+	g := newTokenGame(t, Options{})
+	p0, p1 := g.Players[0], g.Players[1]
+	castSpell(t, g, summonWolves)
+	require.Len(t, p0.Board, 2)
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectDestroy, Target: cards.TargetAllyCreature}, "t_wolf#21")
+	assert.Equal(t, []string{"t_wolf#22"}, collectIDs(p0.Board))
+	assert.Equal(t, []string{"s#1", "fx#1"}, collectIDs(p0.Graveyard), "only the spells")
+	assert.Equal(t, 19, p1.Life, "on_death still fires")
+
+	castAt(t, g, cards.Effect{Kind: cards.EffectReturnToHand, Target: cards.TargetAllyCreature}, "t_wolf#22")
+	assert.Empty(t, p0.Board)
+	assert.Empty(t, p0.Hand)
+	assert.Len(t, p0.Graveyard, 3)
+}
+
+func TestSummon_UnknownToken(t *testing.T) {
+	deck := append(smallDeck(9), cards.CardDef{ID: "s_call", Name: "Call", Type: cards.TypeSpell, Effects: []cards.Effect{summonWolves}})
+
+	_, err := NewGame("p0", "p1", deck, smallDeck(10), Options{Seed: 1})
+	assert.ErrorIs(t, err, ErrUnknownToken)
+
+	_, err = NewGame("p0", "p1", deck, smallDeck(10), Options{Seed: 1, Tokens: []cards.CardDef{wolfToken}})
+	assert.NoError(t, err)
+}
+
+func TestSummon_Snapshot(t *testing.T) {
+	g := newTokenGame(t, Options{})
+	castSpell(t, g, summonWolves)
+
+	data, err := g.MarshalSnapshot()
+	require.NoError(t, err)
+
+	// Token cards come from the saved options
+	defs := append(smallDeck(10), *g.Players[0].Graveyard[0].Def)
+	restored, err := RestoreSnapshot(data, defs)
+	require.NoError(t, err)
+	require.Len(t, restored.Players[0].Board, 2)
+	assert.True(t, restored.Players[0].Board[0].Token)
+	assert.Equal(t, "Wolf", restored.Players[0].Board[0].Def.Name)
+
+	// Both games number the next token the same way
+	for _, game := range []*Game{g, restored} {
+		castSpell(t, game, summonWolves)
+		assert.Equal(t, InstanceID("t_wolf#24"), game.Players[0].Board[3].InstanceID)
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+// maxTriggerDepth bounds nested triggers, e.g. an on_damaged ability that
+// deals damage to its own creature.
+const maxTriggerDepth = 8
+
+// fireTrigger resolves every ability of the source card that matches the
+// trigger. The source is passed by value because the card may already have
+// left its zone (on_death). Triggered effects resolve immediately through
+// runEffects with targets picked relative to the card's controller.
+func (g *Game) fireTrigger(source CardInstance, trigger cards.Trigger) {
+	if len(source.Def.Abilities) == 0 {
+		return
+	}
+
+	controller := g.playerByID(source.Controller)
+	if controller == nil {
+		g.log("error", "", "trigger %s on %s: controller %q not found", trigger, source.InstanceID, source.Controller)
+		return
+	}
+
+	// A card in a hand or deck is only named to its controller
+	name, private := source.Def.Name, fmt.Sprintf("%s (%s)", source.Def.Name, source.InstanceID)
+	public := private
+	if g.inHiddenZone(&source) {
+		name, public = "hidden card", "a hidden card"
+	} else {
+		private = ""
+	}
+
+	if g.triggerDepth >= maxTriggerDepth {
+		g.logPrivate("trigger", controller.PlayerID, private, "%s %s skipped: trigger depth limit reached", public, trigger)
+		return
+	}
+	g.triggerDepth++
+	defer func() { g.triggerDepth-- }()
+
+	for _, ability := range source.Def.Abilities {
+		if ability.Trigger != trigger {
+			continue
+		}
+
+		g.logPrivate("trigger", controller.PlayerID, private, "%s %s", public, trigger)
+
+		var effects []DeferredEffect
+		for i, effect := range ability.Effects {
+			target := g.triggerTarget(effect.Target, &source, controller)
+			if target == nil {
+				g.log("error", controller.PlayerID, "%s %s effect %d: unsupported target %q", name, trigger, i, effect.Target)
+				continue
+			}
+			effects = append(effects, DeferredEffect{Effect: effect, Target: target, Caster: controller.PlayerID, Source: source.InstanceID, SourceCard: &source})
+		}
+		g.runEffects(effects, &source, fmt.Sprintf("%s %s", name, trigger))
+	}
+}
+
+// inHiddenZone reports whether the card is in a hand or deck, where only its
+// controller may see it.
+func (g *Game) inHiddenZone(ci *CardInstance) bool {
+	_, zone, _, err := g.findCardInZonesFromInstance(ci)
+	return err == nil && (zone == ZoneHand || zone == ZoneDeck)
+}
+
+// fireBoardTrigger fires the trigger for every creature currently on the
+// player's board. The board is copied first since abilities may change it.
+func (g *Game) fireBoardTrigger(ps *PlayerState, trigger cards.Trigger) {
+	board := make([]CardInstance, len(ps.Board))
+	copy(board, ps.Board)
+	for _, ci := range board {
+		g.fireTrigger(ci, trigger)
+	}
+}
+
+// triggerTarget picks the target for a triggered effect. Abilities have no
+// player input, so only targets the engine can decide on are supported.
+func (g *Game) triggerTarget(req cards.TargetKind, source *CardInstance, controller *PlayerState) *TargetRef {
+	switch req {
+	case cards.TargetSelf:
+		return &TargetRef{InstanceID: &source.InstanceID}
+	case cards.TargetSelfPlayer:
+		return &TargetRef{PlayerID: controller.PlayerID}
+	case cards.TargetEnemyPlayer:
+		return &TargetRef{PlayerID: g.opponentOf(controller).PlayerID}
+	default:
+		if engineTargeted(req) {
+			return &TargetRef{} // filled in by runEffects
+		}
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
+
+func TestTriggers_OnPlay(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, withAbility(newCreature("h#1", "Scholar", "p0", 1, 1), cards.TriggerOnPlay,
+		cards.Effect{Kind: cards.EffectDrawCards, Amount: 2, Target: cards.TargetSelfPlayer},
+	))
+	deckBefore := len(p0.Deck)
+
+	require.NoError(t, g.PlayCard("p0", 0, nil))
+
+	assert.Len(t, p0.Board, 1)
+	assert.Len(t, p0.Hand, 2, "on_play should draw two cards")
+	assert.Equal(t, deckBefore-2, len(p0.Deck))
+}
+
+func TestTriggers_OnDeath(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+
+	// p1's creature hits p0 (its controller's opponent) when it dies on p0's turn
+	p1.Board = append(p1.Board, withAbility(newCreature("b#1", "Bomb", "p1", 0, 1), cards.TriggerOnDeath,
+		cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyPlayer},
+	))
+	p0.Hand = append(p0.Hand, CardInstance{
+		InstanceID: "s#1",
+		Def: &cards.CardDef{
+			ID: "s_bolt", Name: "Bolt", Type: cards.TypeSpell, Cost: 1,
+			Effects: []cards.Effect{{Kind: cards.EffectDamage, Amount: 2, Target: cards.TargetEnemyCreature}},
+		},
+		Owner:      "p0",
+		Controller: "p0",
+	})
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("b#1")}}))
+
+	assert.Empty(t, p1.Board)
+	assert.Len(t, p1.Graveyard, 1)
+	assert.Equal(t, 17, p0.Life, "on_death should damage the controller's opponent")
+	assert.Equal(t, 20, p1.Life)
+}
+
+func TestTriggers_OnDeathFromMiddleOfBoard(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+
+	// Removing the middle creature shifts the next one into its slot; the
+	// dying creature's ability must still be the one that fires
+	p1.Board = append(p1.Board,
+		newCreature("b#1", "Wolf", "p1", 1, 3),
+		withAbility(newCreature("b#2", "Bomb", "p1", 0, 1), cards.TriggerOnDeath,
+			cards.Effect{Kind: cards.EffectDamage, Amount: 3, Target: cards.TargetEnemyPlayer}),
+		withAbility(newCreature("b#3", "Medic", "p1", 1, 3), cards.TriggerOnDeath,
+			cards.Effect{Kind: cards.EffectHeal, Amount: 5, Target: cards.TargetSelfPlayer}),
+	)
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+	p0.Hand = append(p0.Hand, spellCard("s#1", "p0", "", cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyCreature}))
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("b#2")}}))
+
+	assert.Equal(t, []string{"b#1", "b#3"}, collectIDs(p1.Board))
+	assert.Equal(t, 17, p0.Life, "the bomb's on_death fired")
+	assert.Equal(t, 20, p1.Life, "the medic's on_death did not")
+}
+
+func TestTriggers_OnDeathInCombat(t *testing.T) {
+	g := newCombatGame(t)
+	g.Players[0].Board = append(g.Players[0].Board, withAbility(newCreature("a#1", "Martyr", "p0", 1, 1), cards.TriggerOnDeath,
+		cards.Effect{Kind: cards.EffectHeal, Amount: 4, Target: cards.TargetSelfPlayer},
+	))
+	g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wolf", "p1", 2, 2))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#1"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	assert.Empty(t, g.Players[0].Board)
+	assert.Equal(t, 24, g.Players[0].Life)
+}
+
+func TestTriggers_TurnStartAndEnd(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board, withAbility(
+		withAbility(newCreature("a#1", "Monk", "p0", 1, 3), cards.TriggerOnTurnStart,
+			cards.Effect{Kind: cards.EffectHeal, Amount: 2, Target: cards.TargetSelfPlayer},
+		),
+		cards.TriggerOnTurnEnd,
+		cards.Effect{Kind: cards.EffectBuffStatsPerm, BuffAttack: 1, BuffHealth: 1, Target: cards.TargetSelf},
+	))
+	g.Players[1].Board = append(g.Players[1].Board, withAbility(newCreature("b#1", "Idol", "p1", 0, 3), cards.TriggerOnTurnStart,
+		cards.Effect{Kind: cards.EffectDamage, Amount: 5, Target: cards.TargetEnemyPlayer},
+	))
+
+	g.TurnStarted = false
+	g.StartTurn()
+	assert.Equal(t, 22, p0.Life, "only the active player's creatures trigger")
+
+	g.EndTurn()
+	assert.Equal(t, 2, p0.Board[0].CurrentAttack)
+	assert.Equal(t, 4, p0.Board[0].CurrentHealth)
+
+	g.StartTurn()
+	assert.Equal(t, 17, p0.Life, "p1's creature triggers on p1's turn")
+}
+
+func TestTriggers_OnDamaged(t *testing.T) {
+	g := newCombatGame(t)
+	p0 := g.Players[0]
+	p0.Board = append(p0.Board, withAbility(newCreature("a#1", "Berserker", "p0", 1, 5), cards.TriggerOnDamaged,
+		cards.Effect{Kind: cards.EffectBuffStatsPerm, BuffAttack: 2, Target: cards.TargetSelf},
+	))
+	g.Players[1].Board = append(g.Players[1].Board, newCreature("b#1", "Wolf", "p1", 1, 1))
+
+	require.NoError(t, g.DeclareAttackers("p0", []InstanceID{"a#1"}))
+	require.NoError(t, g.DeclareBlockers("p1", map[InstanceID]InstanceID{"a#1": "b#1"}))
+	require.NoError(t, g.ResolveCombat("p0"))
+
+	require.Len(t, p0.Board, 1)
+	assert.Equal(t, 3, p0.Board[0].CurrentAttack)
+}
+
+func TestTriggers_OnDamagedDepthLimit(t *testing.T) {
+	g := newCombatGame(t)
+	p0, p1 := g.Players[0], g.Players[1]
+	p0.MaxEnergy, p0.CurrentEnergy = 10, 10
+
+	// Each hit triggers another hit on itself; the depth cap must stop it
+	p1.Board = append(p1.Board, withAbility(newCreature("b#1", "Masochist", "p1", 0, 100), cards.TriggerOnDamaged,
+		cards.Effect{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetSelf},
+	))
+	p0.Hand = append(p0.Hand, CardInstance{
+		InstanceID: "s#1",
+		Def: &cards.CardDef{
+			ID: "s_bolt", Name: "Bolt", Type: cards.TypeSpell, Cost: 1,
+			Effects: []cards.Effect{{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyCreature}},
+		},
+		Owner:      "p0",
+		Controller: "p0",
+	})
+
+	require.NoError(t, g.PlayCard("p0", 0, []*TargetRef{{InstanceID: ptrInstance("b#1")}}))
+
+	require.Len(t, p1.Board, 1)
+	assert.Equal(t, 1+maxTriggerDepth, p1.Board[0].CurrentDamage)
+}
+
+func TestTriggers_OnDraw(t *testing.T) {
+	deck := smallDeck(3)
+	for i := range deck {
+		deck[i].Abilities = []cards.Ability{{
+			Trigger: cards.TriggerOnDraw,
+			Effects: []cards.Effect{{Kind: cards.EffectDamage, Amount: 1, Target: cards.TargetEnemyPlayer}},
+		}}
+	}
+
+	g, err := NewGame("p0", "p1", deck, smallDeck(3), Options{StartingHand: 1, FirstPlayerDraws: true, Seed: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 20, g.Players[1].Life, "opening hand must not trigger on_draw")
+
+	g.StartTurn()
+	assert.Equal(t, 19, g.Players[1].Life)
+
+	// The drawn card stays hidden from the opponent
+	drawn := g.Players[0].Hand[len(g.Players[0].Hand)-1]
+	trigger := lastEvent(g, "trigger")
+	require.NotNil(t, trigger)
+	assert.NotContains(t, trigger.Msg, string(drawn.InstanceID))
+	assert.Contains(t, trigger.Private, string(drawn.InstanceID))
+	opponent, err := g.ViewFor("p1")
+	require.NoError(t, err)
+	for _, e := range opponent.Log {
+		assert.NotContains(t, e.Msg+e.Private, string(drawn.InstanceID))
+	}
+}
@@ -1,19 +1,49 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/AdonaIsium/tcg-engine/internal/cards"
 )
 
-func (g *Game) StartTurn() {
+var (
+	ErrTurnStarted    = errors.New("turn already started")
+	ErrTurnNotStarted = errors.New("turn not started")
+)
+
+// StartTurnAction begins the active player's turn: energy ramps and refills,
+// the player draws and their creatures are readied. It can only be taken
+// once per turn.
+type StartTurnAction struct {
+	PlayerID string `json:"player_id"`
+}
+
+func (a StartTurnAction) Validate(g *Game) error {
 	// Validate active index & player
 	if g.Active < 0 || g.Active > 1 || g.Players[g.Active] == nil {
-		g.log("error", "", "invalid active index or nil player: active=%d", g.Active)
-		return
+		return fmt.Errorf("invalid active index or nil player: active=%d", g.Active)
+	}
+
+	if g.CurrentPlayer().PlayerID != a.PlayerID {
+		return ErrNotYourTurn
 	}
 
+	if g.TurnStarted {
+		return ErrTurnStarted
+	}
+
+	if len(g.Stack) > 0 {
+		return ErrStackNotEmpty
+	}
+
+	return nil
+}
+
+func (a StartTurnAction) Apply(g *Game) error {
 	activePlayer := g.CurrentPlayer()
+	g.TurnStarted = true
 
 	// Advance turn counter
 	if g.Turn == 0 {
@@ -38,16 +68,87 @@ func (g *Game) StartTurn() {
 	g.refreshCreatures(activePlayer)
 
 	g.log("start", activePlayer.PlayerID, "start turn: cap=%d energy=%d", activePlayer.MaxEnergy, activePlayer.CurrentEnergy)
+
+	g.fireBoardTrigger(activePlayer, cards.TriggerOnTurnStart)
+	g.resolveStateBasedEffects()
+
+	return nil
 }
 
-func (g *Game) EndTurn() {
+// EndTurnAction ends the active player's turn and passes play to the opponent.
+type EndTurnAction struct {
+	PlayerID string `json:"player_id"`
+}
+
+func (a EndTurnAction) Validate(g *Game) error {
 	if g.Active < 0 || g.Active > 1 || g.Players[g.Active] == nil {
-		g.log("error", "", "invalid active index or nil player in EndTurn: active=%d", g.Active)
+		return fmt.Errorf("invalid active index or nil player in EndTurn: active=%d", g.Active)
+	}
+
+	if g.CurrentPlayer().PlayerID != a.PlayerID {
+		return ErrNotYourTurn
+	}
+
+	if !g.TurnStarted {
+		return ErrTurnNotStarted
+	}
+
+	if len(g.Stack) > 0 {
+		return ErrStackNotEmpty
+	}
+
+	return nil
+}
+
+func (a EndTurnAction) Apply(g *Game) error {
+	g.fireBoardTrigger(g.Players[g.Active], cards.TriggerOnTurnEnd)
+	g.resolveStateBasedEffects()
+
+	g.EndingTurn = true
+	if len(g.Decisions) == 0 {
+		g.continueEndTurn()
+	}
+	return nil
+}
+
+// continueEndTurn finishes ending the turn once no decisions are pending,
+// first asking for a discard if the hand is over the limit.
+func (g *Game) continueEndTurn() {
+	ps := g.Players[g.Active]
+	if n := g.excessCards(ps); n > 0 && !g.GameEnded {
+		g.askDiscard(ps, n)
 		return
 	}
+
+	g.EndingTurn = false
+	g.finishTurn()
+}
+
+// finishTurn cleans up and passes play to the opponent.
+func (g *Game) finishTurn() {
 	g.log("end", g.Players[g.Active].PlayerID, "end turn")
 	g.CleanupTurn()
+	g.clearCombat()
 	g.Active = 1 - g.Active
+	g.TurnStarted = false
+}
+
+// StartTurn starts the current player's turn.
+func (g *Game) StartTurn() error {
+	var playerID string
+	if g.Active >= 0 && g.Active <= 1 && g.Players[g.Active] != nil {
+		playerID = g.CurrentPlayer().PlayerID
+	}
+	return g.Submit(StartTurnAction{PlayerID: playerID})
+}
+
+// EndTurn ends the current player's turn.
+func (g *Game) EndTurn() error {
+	var playerID string
+	if g.Active >= 0 && g.Active <= 1 && g.Players[g.Active] != nil {
+		playerID = g.CurrentPlayer().PlayerID
+	}
+	return g.Submit(EndTurnAction{PlayerID: playerID})
 }
 
 func (g *Game) CurrentPlayer() *PlayerState {
@@ -58,20 +159,63 @@ func (g *Game) Opponent() *PlayerState {
 	return g.Players[1-g.Active]
 }
 
+// opponentOf returns the other player relative to ps, regardless of whose
+// turn it is.
+func (g *Game) opponentOf(ps *PlayerState) *PlayerState {
+	if g.Players[0] == ps {
+		return g.Players[1]
+	}
+	return g.Players[0]
+}
+
+func (g *Game) playerIndex(ps *PlayerState) int {
+	if g.Players[0] == ps {
+		return 0
+	}
+	return 1
+}
+
+func (g *Game) playerByID(playerID string) *PlayerState {
+	for _, p := range g.Players {
+		if p.PlayerID == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// Draw moves up to n cards from the top of the player's deck to their hand
+// and returns how many were drawn. Drawing from an empty deck is handled by
+// the DeckOut option; the state-based check settles any resulting loss.
 func (g *Game) Draw(player *PlayerState, n int) int {
-	drawn := 0
+	var drawnCards []CardInstance
 	for range n {
 		if len(player.Deck) == 0 {
-			break
+			g.deckOut(player)
+			if len(player.Deck) == 0 {
+				if g.Options.DeckOut == DeckOutFatigue {
+					continue // every missed draw deals fatigue
+				}
+				break
+			}
 		}
 		top := len(player.Deck) - 1
 		card := player.Deck[top]
 		player.Deck = player.Deck[:top]
+		if g.handFull(player) {
+			g.burnCard(player, card)
+			continue
+		}
 		player.Hand = append(player.Hand, card)
-		drawn++
+		drawnCards = append(drawnCards, card)
 	}
+	drawn := len(drawnCards)
 
-	g.log("draw", player.PlayerID, "drew %d", drawn)
+	g.logPrivate("draw", player.PlayerID, cardNames(drawnCards), "drew %d", drawn)
+
+	for _, card := range drawnCards {
+		g.fireTrigger(card, cards.TriggerOnDraw)
+	}
 
 	return drawn
 }
@@ -81,6 +225,7 @@ func (g *Game) refreshCreatures(ps *PlayerState) {
 		ci := &ps.Board[i]
 		if ci.Def.Type == cards.TypeCreature {
 			ci.Exhausted = false
+			ci.AttackedThisTurn = false
 			if ci.SummoningSick {
 				ci.SummoningSick = false
 			}
@@ -101,9 +246,7 @@ func (g *Game) refreshCreatureHealth() {
 				card.TempAttackBuff = 0
 				card.TempHealthBuff = 0
 
-				// Recalculate attack/health using only permanent statuses
-				card.CurrentAttack = card.Def.Attack + card.PermAttackBuff
-				card.CurrentHealth = card.Def.Health + card.PermHealthBuff
+				computeStats(card)
 				g.log("refresh_creature_after", g.Players[i].PlayerID, "%s (%s) attack/health refreshed to %d/%d", card.Def.Name, card.InstanceID, card.CurrentAttack, card.CurrentHealth)
 			}
 		}
@@ -118,3 +261,18 @@ func (g *Game) log(eventType, playerID, format string, args ...any) {
 	msg := fmt.Sprintf(format, args...)
 	g.Log = append(g.Log, Event{Turn: g.Turn, Player: playerID, Type: eventType, Msg: msg})
 }
+
+// logPrivate logs a public message plus details only playerID may see.
+func (g *Game) logPrivate(eventType, playerID, private, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	g.Log = append(g.Log, Event{Turn: g.Turn, Player: playerID, Type: eventType, Msg: msg, Private: private})
+}
+
+// cardNames lists the cards as "Name (instance)", comma separated.
+func cardNames(insts []CardInstance) string {
+	names := make([]string, len(insts))
+	for i, ci := range insts {
+		names[i] = fmt.Sprintf("%s (%s)", ci.Def.Name, ci.InstanceID)
+	}
+	return strings.Join(names, ", ")
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/AdonaIsium/tcg-engine/internal/cards"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 )
 
 func TestEndTurn_FlipsActiveOnly(t *testing.T) {
@@ -15,12 +14,7 @@ func TestEndTurn_FlipsActiveOnly(t *testing.T) {
 		StartingHand: 3,
 		MaxEnergy:    10,
 	}
-	p1ID, p2ID := "p1", "p2"
-	d1 := smallDeck(8)
-	d2 := smallDeck(8)
-
-	g, err := NewGame(p1ID, p2ID, d1, d2, opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 8, opts)
 
 	startActive := g.Active
 	startTurn := g.Turn
@@ -48,12 +42,7 @@ func TestEndTurn_FlipsActiveOnly(t *testing.T) {
 
 func TestEndTurn_MultipleCallsAlternateActive(t *testing.T) {
 	opts := Options{Seed: 999, StartingLife: 20, StartingHand: 3, MaxEnergy: 10}
-	p1ID, p2ID := "p1", "p2"
-	d1 := smallDeck(6)
-	d2 := smallDeck(6)
-
-	g, err := NewGame(p1ID, p2ID, d1, d2, opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 6, opts)
 
 	baseTurn := g.Turn
 	baseP0 := clonePlayerState(g.Players[0])
@@ -63,6 +52,7 @@ func TestEndTurn_MultipleCallsAlternateActive(t *testing.T) {
 	want := 1
 
 	for range 4 {
+		g.TurnStarted = true // EndTurn closes the turn, so reopen it each time
 		g.EndTurn()
 		assert.Equal(t, want, g.Active, "active should alternate")
 		assert.Equal(t, baseTurn, g.Turn, "turn counter should not change")
@@ -85,8 +75,7 @@ func TestEndTurn_DamageClearsAtEndOfTurn(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Setup: Place a creature on each player's board
 	p1Creature := CardInstance{
@@ -154,8 +143,7 @@ func TestEndTurn_TemporaryBuffsExpire(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Setup: Place creatures with temporary buffs
 	buffedCreature := CardInstance{
@@ -227,8 +215,7 @@ func TestEndTurn_PermanentBuffsPersist(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Setup: Creature with permanent buffs and damage
 	permBuffedCreature := CardInstance{
@@ -280,8 +267,7 @@ func TestEndTurn_ComplexCreatureState(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Setup: Complex creature state
 	complexCreature := CardInstance{
@@ -367,8 +353,7 @@ func TestEndTurn_NoCreaturesNoErrors(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Both boards are empty
 	assert.Empty(t, g.Players[0].Board)
@@ -392,8 +377,7 @@ func TestEndTurn_OnlyUndamagedCreatures(t *testing.T) {
 		MaxEnergy:    10,
 	}
 
-	g, err := NewGame("p1", "p2", smallDeck(10), smallDeck(10), opts)
-	require.NoError(t, err)
+	g := newStartedGame(t, 10, opts)
 
 	// Setup: Healthy creatures with no modifications
 	healthyCreature := CardInstance{
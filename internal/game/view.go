@@ -0,0 +1,206 @@
+package game
+
+// View is the game as one participant is allowed to see it. Hands and decks
+// the viewer can't see are reduced to counts and private log details are
+// dropped, so a View can be sent to a client as-is.
+type View struct {
+	GameID    string          `json:"game_id"`
+	Viewer    string          `json:"viewer,omitempty"` // empty for spectators
+	Turn      int             `json:"turn"`
+	Active    string          `json:"active"`
+	Priority  string          `json:"priority,omitempty"`
+	Players   []PlayerView    `json:"players"`
+	Stack     []StackItemView `json:"stack"`
+	Combat    CombatView      `json:"combat"`
+	Mulligan  bool            `json:"mulligan,omitempty"` // pre-game mulligan phase is running
+	Decision  *DecisionView   `json:"decision,omitempty"` // decision the game is waiting on
+	GameEnded bool            `json:"game_ended"`
+	Winner    string          `json:"winner,omitempty"`
+	Log       []Event         `json:"log"`
+}
+
+type PlayerView struct {
+	PlayerID      string     `json:"player_id"`
+	Name          string     `json:"name"`
+	Life          int        `json:"life"`
+	CurrentEnergy int        `json:"current_energy"`
+	MaxEnergy     int        `json:"max_energy"`
+	DeckCount     int        `json:"deck_count"`
+	HandCount     int        `json:"hand_count"`
+	Hand          []CardView `json:"hand,omitempty"` // only for the viewer's own hand
+	Board         []CardView `json:"board"`
+	Graveyard     []CardView `json:"graveyard"`
+	Mulligans     int        `json:"mulligans,omitempty"`
+	HandKept      bool       `json:"hand_kept,omitempty"`
+	Fatigue       int        `json:"fatigue,omitempty"`
+}
+
+type CardView struct {
+	InstanceID    InstanceID `json:"instance_id"`
+	CardID        string     `json:"card_id"`
+	Name          string     `json:"name"`
+	Type          string     `json:"type"`
+	Cost          int        `json:"cost"`
+	Text          string     `json:"text,omitempty"`
+	Owner         string     `json:"owner"`
+	Controller    string     `json:"controller"`
+	Attack        int        `json:"attack,omitempty"`
+	Health        int        `json:"health,omitempty"`
+	SummoningSick bool       `json:"summoning_sick,omitempty"`
+	Exhausted     bool       `json:"exhausted,omitempty"`
+	DivineShield  bool       `json:"divine_shield,omitempty"`
+	Frozen        bool       `json:"frozen,omitempty"`
+	Token         bool       `json:"token,omitempty"`
+}
+
+type StackItemView struct {
+	Card    CardView     `json:"card"`
+	Caster  string       `json:"caster"`
+	Targets []*TargetRef `json:"targets,omitempty"`
+}
+
+// DecisionView shows who the game is waiting on. Only the deciding player
+// sees the options, since they may name hidden cards.
+type DecisionView struct {
+	ID       int              `json:"id"`
+	PlayerID string           `json:"player_id"`
+	Kind     DecisionKind     `json:"kind"`
+	Prompt   string           `json:"prompt"`
+	Count    int              `json:"count"`
+	UpTo     bool             `json:"up_to,omitempty"`
+	Options  []DecisionOption `json:"options,omitempty"`
+}
+
+type CombatView struct {
+	Phase     CombatPhase               `json:"phase"`
+	Attackers []InstanceID              `json:"attackers,omitempty"`
+	Targets   map[InstanceID]InstanceID `json:"targets,omitempty"` // attacker -> creature it attacks
+	Blocks    map[InstanceID]InstanceID `json:"blocks,omitempty"`
+}
+
+// ViewFor returns the game as playerID sees it: their own hand in full, the
+// opponent's hand and both decks as counts, and only their own private log
+// details.
+func (g *Game) ViewFor(playerID string) (*View, error) {
+	if g.playerByID(playerID) == nil {
+		return nil, ErrPlayerNotFound
+	}
+	return g.view(playerID), nil
+}
+
+// SpectatorView returns the game with only public information.
+func (g *Game) SpectatorView() *View {
+	return g.view("")
+}
+
+func (g *Game) view(viewer string) *View {
+	v := &View{
+		GameID:    g.ID,
+		Viewer:    viewer,
+		Turn:      g.Turn,
+		Active:    g.CurrentPlayer().PlayerID,
+		Players:   make([]PlayerView, 0, len(g.Players)),
+		Stack:     make([]StackItemView, 0, len(g.Stack)),
+		Mulligan:  g.inMulligan(),
+		Decision:  decisionView(g.PendingDecision(), viewer),
+		GameEnded: g.GameEnded,
+		Winner:    g.Winner,
+		Log:       make([]Event, 0, len(g.Log)),
+		Combat: CombatView{
+			Phase:     g.CombatPhase,
+			Attackers: append([]InstanceID(nil), g.AttackingIDs...),
+			Targets:   make(map[InstanceID]InstanceID, len(g.AttackTargets)),
+			Blocks:    make(map[InstanceID]InstanceID, len(g.BlockingPairs)),
+		},
+	}
+
+	if len(g.Stack) > 0 {
+		v.Priority = g.priorityPlayer().PlayerID
+	}
+
+	for _, p := range g.Players {
+		pv := PlayerView{
+			PlayerID:      p.PlayerID,
+			Name:          p.Name,
+			Life:          p.Life,
+			CurrentEnergy: p.CurrentEnergy,
+			MaxEnergy:     p.MaxEnergy,
+			DeckCount:     len(p.Deck),
+			HandCount:     len(p.Hand),
+			Board:         cardViews(p.Board),
+			Graveyard:     cardViews(p.Graveyard),
+			Mulligans:     p.Mulligans,
+			HandKept:      p.HandKept,
+			Fatigue:       p.Fatigue,
+		}
+		for i := range pv.Board {
+			pv.Board[i].Frozen = g.frozen(&p.Board[i])
+		}
+		if viewer != "" && p.PlayerID == viewer {
+			pv.Hand = cardViews(p.Hand)
+		}
+		v.Players = append(v.Players, pv)
+	}
+
+	for _, item := range g.Stack {
+		v.Stack = append(v.Stack, StackItemView{
+			Card:    cardView(item.Card),
+			Caster:  item.Caster,
+			Targets: item.Targets,
+		})
+	}
+
+	for attackerID, targetID := range g.AttackTargets {
+		v.Combat.Targets[attackerID] = targetID
+	}
+	for attackerID, blockerID := range g.BlockingPairs {
+		v.Combat.Blocks[attackerID] = blockerID
+	}
+
+	for _, e := range g.Log {
+		if e.Player != viewer || viewer == "" {
+			e.Private = ""
+		}
+		v.Log = append(v.Log, e)
+	}
+
+	return v
+}
+
+func decisionView(d *PendingDecision, viewer string) *DecisionView {
+	if d == nil {
+		return nil
+	}
+	dv := &DecisionView{ID: d.ID, PlayerID: d.PlayerID, Kind: d.Kind, Prompt: d.Prompt, Count: d.Count, UpTo: d.UpTo}
+	if viewer != "" && viewer == d.PlayerID {
+		dv.Options = d.Options
+	}
+	return dv
+}
+
+func cardView(ci CardInstance) CardView {
+	return CardView{
+		InstanceID:    ci.InstanceID,
+		CardID:        ci.Def.ID,
+		Name:          ci.Def.Name,
+		Type:          string(ci.Def.Type),
+		Cost:          ci.Def.Cost,
+		Text:          ci.Def.Text,
+		Owner:         ci.Owner,
+		Controller:    ci.Controller,
+		Attack:        ci.CurrentAttack,
+		Health:        ci.CurrentHealth,
+		SummoningSick: ci.SummoningSick,
+		Exhausted:     ci.Exhausted,
+		DivineShield:  ci.DivineShield,
+		Token:         ci.Token,
+	}
+}
+
+func cardViews(insts []CardInstance) []CardView {
+	out := make([]CardView, 0, len(insts))
+	for _, ci := range insts {
+		out = append(out, cardView(ci))
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewFor_RedactsHiddenZones(t *testing.T) {
+	g, err := NewGame("p1", "p2", makeDeck(10), makeDeck(10), Options{StartingHand: 3, Seed: 7})
+	require.NoError(t, err)
+	require.NoError(t, g.StartTurn())
+
+	v, err := g.ViewFor("p1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "p1", v.Viewer)
+	require.Len(t, v.Players, 2)
+
+	own, opp := v.Players[0], v.Players[1]
+	assert.Len(t, own.Hand, len(g.Players[0].Hand))
+	assert.Equal(t, len(g.Players[0].Hand), own.HandCount)
+	assert.Equal(t, len(g.Players[0].Deck), own.DeckCount)
+
+	assert.Nil(t, opp.Hand, "opponent hand must not be visible")
+	assert.Equal(t, len(g.Players[1].Hand), opp.HandCount)
+	assert.Equal(t, len(g.Players[1].Deck), opp.DeckCount)
+
+	// Nothing about either deck's order or the opponent's hand may leak into the JSON
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	for _, ci := range g.Players[1].Hand {
+		assert.NotContains(t, string(data), string(ci.InstanceID))
+	}
+	for _, p := range g.Players {
+		for _, ci := range p.Deck {
+			assert.NotContains(t, string(data), string(ci.InstanceID))
+		}
+	}
+}
+
+func TestViewFor_RedactsPrivateLogDetails(t *testing.T) {
+	g, err := NewGame("p1", "p2", makeDeck(10), makeDeck(10), Options{StartingHand: 3, Seed: 7})
+	require.NoError(t, err)
+	require.NoError(t, g.StartTurn())
+
+	drawn := g.Players[0].Hand[len(g.Players[0].Hand)-1]
+
+	privateFor := func(v *View, playerID string) []string {
+		var out []string
+		for _, e := range v.Log {
+			if e.Player == playerID && e.Private != "" {
+				out = append(out, e.Private)
+			}
+		}
+		return out
+	}
+
+	own, err := g.ViewFor("p1")
+	require.NoError(t, err)
+	ownPrivate := privateFor(own, "p1")
+	require.NotEmpty(t, ownPrivate)
+	assert.Contains(t, ownPrivate[len(ownPrivate)-1], string(drawn.InstanceID))
+	assert.Empty(t, privateFor(own, "p2"))
+
+	opp, err := g.ViewFor("p2")
+	require.NoError(t, err)
+	assert.Empty(t, privateFor(opp, "p1"))
+	assert.NotEmpty(t, privateFor(opp, "p2"))
+
+	// Public messages stay intact for everyone
+	require.Len(t, opp.Log, len(g.Log))
+	for i := range g.Log {
+		assert.Equal(t, g.Log[i].Msg, opp.Log[i].Msg)
+	}
+
+	// The game's own log is untouched
+	assert.Equal(t, ownPrivate, privateFor(&View{Log: g.Log}, "p1"))
+}
+
+func TestSpectatorView(t *testing.T) {
+	g, err := NewGame("p1", "p2", makeDeck(10), makeDeck(10), Options{StartingHand: 3, Seed: 7})
+	require.NoError(t, err)
+	require.NoError(t, g.StartTurn())
+
+	v := g.SpectatorView()
+	assert.Empty(t, v.Viewer)
+	for i, pv := range v.Players {
+		assert.Nil(t, pv.Hand)
+		assert.Equal(t, len(g.Players[i].Hand), pv.HandCount)
+		assert.Len(t, pv.Board, len(g.Players[i].Board))
+	}
+	for _, e := range v.Log {
+		assert.Empty(t, e.Private)
+	}
+}
+
+func TestViewFor_UnknownPlayer(t *testing.T) {
+	g, err := NewGame("p1", "p2", makeDeck(10), makeDeck(10), Options{Seed: 7})
+	require.NoError(t, err)
+
+	_, err = g.ViewFor("nobody")
+	assert.ErrorIs(t, err, ErrPlayerNotFound)
+}
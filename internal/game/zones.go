@@ -1,6 +1,10 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/AdonaIsium/tcg-engine/internal/cards"
+)
 
 func (g *Game) moveToGraveyard(cardInstance *CardInstance, reason string) error {
 	player, zone, i, err := g.findCardInZonesFromInstance(cardInstance)
@@ -8,6 +12,11 @@ func (g *Game) moveToGraveyard(cardInstance *CardInstance, reason string) error
 		return fmt.Errorf("error finding zone: %w", err)
 	}
 
+	// cardInstance may point into the zone slice, which shifts once the card
+	// is removed
+	card := *cardInstance
+	cardInstance = &card
+
 	var ownerPlayer *PlayerState
 	for _, p := range g.Players {
 		if p.PlayerID == cardInstance.Owner {
@@ -25,14 +34,12 @@ func (g *Game) moveToGraveyard(cardInstance *CardInstance, reason string) error
 		} else {
 			player.Board = player.Board[:i]
 		}
-		ownerPlayer.Graveyard = append(ownerPlayer.Graveyard, *cardInstance)
 	case ZoneHand:
 		if i+1 < len(player.Hand) {
 			player.Hand = append(player.Hand[:i], player.Hand[i+1:]...)
 		} else {
 			player.Hand = player.Hand[:i]
 		}
-		ownerPlayer.Graveyard = append(ownerPlayer.Graveyard, *cardInstance)
 	case ZoneGraveyard:
 		return fmt.Errorf("unable to move card from graveyard to graveyard")
 	case ZoneDeck:
@@ -41,12 +48,21 @@ func (g *Game) moveToGraveyard(cardInstance *CardInstance, reason string) error
 		} else {
 			player.Deck = player.Deck[:i]
 		}
-		ownerPlayer.Graveyard = append(ownerPlayer.Graveyard, *cardInstance)
 	default:
 		return fmt.Errorf("unexpected zone %s found", zone)
 	}
 
-	g.log("graveyard", cardInstance.Owner, "%s moved to graveyard (%s)", cardInstance.Def.Name, reason)
+	// Tokens cease to exist instead of reaching the graveyard
+	if cardInstance.Token {
+		g.log("token", cardInstance.Owner, "%s ceased to exist (%s)", cardInstance.Def.Name, reason)
+	} else {
+		ownerPlayer.Graveyard = append(ownerPlayer.Graveyard, *cardInstance)
+		g.log("graveyard", cardInstance.Owner, "%s moved to graveyard (%s)", cardInstance.Def.Name, reason)
+	}
+
+	if zone == ZoneBoard {
+		g.fireTrigger(*cardInstance, cards.TriggerOnDeath)
+	}
 	return nil
 }
 
@@ -70,3 +86,90 @@ func (g *Game) findCardInZonesFromInstance(cardInstance *CardInstance) (*PlayerS
 
 	return nil, "", -1, fmt.Errorf("card %s (%s) not found in any zone", cardInstance.Def.Name, cardInstance.InstanceID)
 }
+
+// findInGraveyard returns the player whose graveyard holds the card, and its
+// index there.
+func (g *Game) findInGraveyard(id InstanceID) (*PlayerState, int, bool) {
+	for _, p := range g.Players {
+		for i := range p.Graveyard {
+			if p.Graveyard[i].InstanceID == id {
+				return p, i, true
+			}
+		}
+	}
+	return nil, -1, false
+}
+
+// takeFromGraveyard removes the card from whichever graveyard holds it.
+func (g *Game) takeFromGraveyard(id InstanceID) (CardInstance, bool) {
+	p, i, ok := g.findInGraveyard(id)
+	if !ok {
+		return CardInstance{}, false
+	}
+	card := p.Graveyard[i]
+	p.Graveyard = append(p.Graveyard[:i], p.Graveyard[i+1:]...)
+	return card, true
+}
+
+// putInGraveyard places a card that is not in any zone (e.g. a spell leaving
+// the stack) into its owner's graveyard. Tokens are simply dropped.
+func (g *Game) putInGraveyard(card CardInstance) {
+	owner := g.playerByID(card.Owner)
+	if owner == nil {
+		owner = g.playerByID(card.Controller)
+	}
+	if owner == nil {
+		g.log("error", "", "putInGraveyard: owner %s not found for %s", card.Owner, card.InstanceID)
+		return
+	}
+	if card.Token {
+		return
+	}
+	owner.Graveyard = append(owner.Graveyard, card)
+}
+
+// shuffle reorders cards using the game's seeded source, so replays see the
+// same order.
+func (g *Game) shuffle(insts []CardInstance) {
+	g.Rand.Shuffle(len(insts), func(i, j int) { insts[i], insts[j] = insts[j], insts[i] })
+}
+
+// dealCards moves up to n cards from the top of the deck to the hand without
+// counting as a draw: nothing is logged and no on_draw triggers fire.
+func (g *Game) dealCards(ps *PlayerState, n int) {
+	for range n {
+		if len(ps.Deck) == 0 {
+			break
+		}
+		top := len(ps.Deck) - 1
+		ps.Hand = append(ps.Hand, ps.Deck[top])
+		ps.Deck = ps.Deck[:top]
+	}
+}
+
+// deckOut applies Options.DeckOut when the player has to draw from an empty
+// deck. Only a reshuffle leaves cards to draw afterwards.
+func (g *Game) deckOut(ps *PlayerState) {
+	switch g.Options.DeckOut {
+	case DeckOutLose:
+		if !ps.DeckedOut {
+			ps.DeckedOut = true
+			g.log("deck_out", ps.PlayerID, "%s tried to draw from an empty deck", ps.PlayerID)
+		}
+
+	case DeckOutFatigue:
+		ps.Fatigue++
+		ps.Life -= ps.Fatigue
+		g.log("fatigue", ps.PlayerID, "%s takes %d fatigue damage", ps.PlayerID, ps.Fatigue)
+
+	case DeckOutReshuffle:
+		if len(ps.Graveyard) == 0 {
+			g.log("deck_out", ps.PlayerID, "%s has no cards left to reshuffle", ps.PlayerID)
+			return
+		}
+		ps.Deck = append(ps.Deck, ps.Graveyard...)
+		ps.Graveyard = nil
+		g.shuffle(ps.Deck)
+		g.log("reshuffle", ps.PlayerID, "%s shuffles %d cards from the graveyard into their deck", ps.PlayerID, len(ps.Deck))
+	}
+}